@@ -1,28 +1,44 @@
 package main
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"log"
 	"os"
+	"os/signal"
 	"strconv"
+	"sync"
+	"syscall"
 	"time"
 
 	"github.com/guidebee/jobseeker/internal/analyzer"
+	"github.com/guidebee/jobseeker/internal/classify"
 	"github.com/guidebee/jobseeker/internal/database"
+	"github.com/guidebee/jobseeker/internal/dedup"
+	"github.com/guidebee/jobseeker/internal/geo"
+	"github.com/guidebee/jobseeker/internal/profile"
+	"github.com/guidebee/jobseeker/pkg/llm"
 	"github.com/spf13/cobra"
 )
 
 var (
 	analyzeContractOnly bool
 	analyzeJobType      string
+	analyzeAllLocations bool
+	analyzeConcurrency  int
+	analyzeForce        bool
 )
 
 var analyzeCmd = &cobra.Command{
 	Use:   "analyze",
-	Short: "Analyze jobs using Claude AI",
-	Long:  `Uses Claude AI to analyze unanalyzed jobs and provide match scores and recommendations.`,
-	Run:   runAnalyze,
+	Short: "Analyze jobs using AI",
+	Long: `Uses AI (MiniMax by default, see ai: in the config) to analyze unanalyzed jobs and provide match scores and recommendations.
+
+Prompts the AI has answered before are answered from the AI cache (see
+'jobseeker cache'), so --force re-scores jobs whose prompts haven't changed
+without paying for them again.`,
+	Run: runAnalyze,
 }
 
 func runAnalyze(cmd *cobra.Command, args []string) {
@@ -39,17 +55,17 @@ func runAnalyze(cmd *cobra.Command, args []string) {
 	}
 	fmt.Printf("Analyzing jobs for: %s (%s)\n", user.Name, user.Email)
 
-	// Get MiniMax API key (used for bulk job analysis)
-	apiKey := os.Getenv("MINIMAX_API_KEY")
-	if apiKey == "" {
-		log.Fatal("MINIMAX_API_KEY not set in environment")
+	// Bulk analysis uses MiniMax unless ai.analysis in the config says otherwise
+	provider, err := newProvider(prof.AI, llm.TaskAnalysis)
+	if err != nil {
+		log.Fatalf("%v", err)
 	}
 
 	// Get match threshold
 	threshold, _ := strconv.Atoi(getEnv("MATCH_THRESHOLD", "70"))
 
 	// Create analyzer
-	a := analyzer.NewAnalyzer(apiKey, prof)
+	a := analyzer.NewAnalyzer(provider, prof)
 
 	// Try to load resumes from resumes directory
 	resumesDir := "./resumes"
@@ -68,14 +84,24 @@ func runAnalyze(cmd *cobra.Command, args []string) {
 		fmt.Println("✓ Using resume(s) for analysis")
 	}
 
-	fmt.Println("Analyzing jobs with MiniMax AI...")
+	fmt.Println("Analyzing jobs with AI...")
 
-	// Get unanalyzed jobs from database
+	// Cluster copies of the same role so that each role is analysed once
+	if stats, err := dedup.ClusterUserJobs(user.ID); err != nil {
+		log.Printf("Warning: %v", err)
+	} else if stats.Duplicates > 0 {
+		fmt.Printf("✓ %d jobs are copies of another listing (%d roles)\n", stats.Duplicates, stats.Clusters)
+	}
+
+	// Get unanalyzed jobs from database, or all of them with --force
 	db := database.GetDB()
 	var jobs []database.Job
 
 	// Build query with job type filter and user filter
-	query := db.Where("user_id = ? AND is_analyzed = ?", user.ID, false)
+	query := db.Where("user_id = ?", user.ID)
+	if !analyzeForce {
+		query = query.Where("is_analyzed = ?", false)
+	}
 
 	if analyzeContractOnly {
 		query = query.Where("job_type = ?", "contract")
@@ -85,76 +111,316 @@ func runAnalyze(cmd *cobra.Command, args []string) {
 		fmt.Printf("Filtering for %s roles only...\n", analyzeJobType)
 	}
 
-	result := query.Find(&jobs)
+	// Newer postings first: they are the ones still worth applying for
+	result := query.Order(freshness).Find(&jobs)
 	if result.Error != nil {
 		log.Fatalf("Failed to fetch jobs: %v", result.Error)
 	}
 
+	// Jobs too far from every preferred location aren't worth an AI call
+	if !analyzeAllLocations {
+		jobs = skipOutOfArea(jobs, prof)
+	}
+
 	if len(jobs) == 0 {
 		fmt.Println("No new jobs to analyze")
 		return
 	}
 
-	fmt.Printf("Found %d jobs to analyze\n\n", len(jobs))
+	// Copies of the same role on other boards share one analysis
+	groups := dedup.Group(jobs)
+	fmt.Printf("Found %d jobs to analyze (%d distinct roles)\n", len(jobs), len(groups))
+	workers := max(analyzeConcurrency, 1)
+	if workers > 1 {
+		fmt.Printf("Analyzing %d roles at a time\n", workers)
+	}
+	fmt.Println()
 
-	recommended := 0
-	for i, job := range jobs {
-		fmt.Printf("[%d/%d] Analyzing: %s at %s (%s)\n", i+1, len(jobs), job.Title, job.Company, job.JobType)
+	// Ctrl-C stops handing out roles; those already sent to the AI are still
+	// saved, so nothing paid for is lost or sent again by the next run
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	finishing := make(chan struct{})
+	defer close(finishing)
+	go func() {
+		select {
+		case <-ctx.Done():
+			stop() // a second Ctrl-C quits at once
+			select {
+			case <-finishing:
+			default:
+				fmt.Println("\n⏸ Stopping once the roles in progress are saved (Ctrl-C again to quit now)")
+			}
+		case <-finishing:
+		}
+	}()
 
-		// Analyze with Claude
-		analysis, err := a.AnalyzeJob(&job)
-		if err != nil {
-			log.Printf("  ✗ Error: %v", err)
+	recommended, rejected, copied, failed, cached, done := 0, 0, 0, 0, 0, 0
+	for r := range analyzeGroups(ctx, a, groups, workers) {
+		if r.err != nil && ctx.Err() != nil {
+			// Given up while waiting for the rate limit; left for the next run
 			continue
 		}
-
-		// Update job with analysis results
-		now := time.Now()
-		job.MatchScore = analysis.MatchScore
-
-		// Store full formatted analysis (for display)
-		job.Analysis = fmt.Sprintf("Score: %d/100\n\nReasoning: %s\n\nPros:\n- %s\n\nCons:\n- %s",
-			analysis.MatchScore,
-			analysis.Reasoning,
-			joinStrings(analysis.Pros, "\n- "),
-			joinStrings(analysis.Cons, "\n- "),
-		)
-
-		// Store structured data (for querying)
-		job.AnalysisReasoning = analysis.Reasoning
-		prosJSON, _ := json.Marshal(analysis.Pros)
-		job.AnalysisPros = string(prosJSON)
-		consJSON, _ := json.Marshal(analysis.Cons)
-		job.AnalysisCons = string(consJSON)
-
-		// Record which resume was used (if any)
-		if a.UseResumes() {
-			job.ResumeUsed = a.GetResumeUsed(&job)
+		done++
+		job := &r.group[dedup.Representative(r.group)]
+		source := r.source
+		switch {
+		case r.err != nil:
+			failed++
+			fmt.Printf("[%d/%d] %s at %s (%s)\n", done, len(groups), job.Title, job.Company, job.JobType)
+			log.Printf("  ✗ Error: %v", r.err)
+			continue
+		case r.reused:
+			fmt.Printf("[%d/%d] Reusing analysis of %s at %s (%s)\n", done, len(groups), source.Title, source.Company, source.Source)
+		case r.cached:
+			cached++
+			fmt.Printf("[%d/%d] %s at %s (%s, cached)\n", done, len(groups), job.Title, job.Company, job.JobType)
+		default:
+			fmt.Printf("[%d/%d] %s at %s (%s)\n", done, len(groups), job.Title, job.Company, job.JobType)
 		}
 
-		job.IsAnalyzed = true
-		job.AnalyzedAt = &now
-
 		// Set status based on threshold
-		if analysis.MatchScore >= threshold {
-			job.Status = "recommended"
-			recommended++
-			fmt.Printf("  ✓ Match: %d/100 - RECOMMENDED\n", analysis.MatchScore)
+		status := database.StatusRejected
+		if source.MatchScore >= threshold {
+			status = database.StatusRecommended
+			fmt.Printf("  ✓ Match: %d/100 - RECOMMENDED\n", source.MatchScore)
 		} else {
-			job.Status = "rejected"
-			fmt.Printf("  ○ Match: %d/100 - Below threshold\n", analysis.MatchScore)
+			fmt.Printf("  ○ Match: %d/100 - Below threshold\n", source.MatchScore)
 		}
 
-		// Save to database
-		db.Save(&job)
+		copies := 0
+		for k := range r.group {
+			member := &r.group[k]
+			if member.ID != source.ID {
+				copyAnalysis(member, source)
+				copies++
+			} else {
+				*member = *source
+			}
+			// A job the user approved or applied for keeps its status
+			if member.Status != database.StatusApproved && member.Status != database.StatusApplied {
+				member.Status = status
+			}
+			if status == database.StatusRecommended {
+				recommended++
+			} else {
+				rejected++
+			}
+
+			// Saved as each role finishes, so an interrupted run keeps its progress
+			if err := db.Save(member).Error; err != nil {
+				log.Printf("  Warning: failed to save job %d: %v", member.ID, err)
+			}
+		}
+		if copies > 0 && len(r.group) > 1 {
+			fmt.Printf("  = Applied to %d copies of this role\n", copies)
+		}
+		copied += copies
 	}
 
-	fmt.Printf("\n✓ Analysis complete!\n")
+	if ctx.Err() != nil && done < len(groups) {
+		fmt.Printf("\n⏸ Interrupted: %d of %d roles analyzed; run 'jobseeker analyze' again for the rest\n", done-failed, len(groups))
+	} else {
+		fmt.Printf("\n✓ Analysis complete!\n")
+	}
 	fmt.Printf("  Recommended jobs: %d\n", recommended)
-	fmt.Printf("  Below threshold: %d\n", len(jobs)-recommended)
+	fmt.Printf("  Below threshold: %d\n", rejected)
+	if failed > 0 {
+		fmt.Printf("  Failed (retried next run): %d\n", failed)
+	}
+	if copied > 0 {
+		fmt.Printf("  Copies given their role's analysis: %d\n", copied)
+	}
+	if cached > 0 {
+		fmt.Printf("  Answered from the AI cache: %d\n", cached)
+	}
 	fmt.Println("\nRun 'jobseeker list --recommended' to see your matches")
 }
 
+// groupResult is the outcome of analysing one role (a group of copies of a
+// job). source is the job whose analysis the group takes: the analysed
+// representative, or a copy analysed earlier if reused is set. cached is set
+// if the analysis came from the AI cache.
+type groupResult struct {
+	group  []database.Job
+	source *database.Job
+	reused bool
+	cached bool
+	err    error
+}
+
+// analyzeGroups analyses each group's representative on a pool of workers
+// and sends the results as they finish. Once ctx is cancelled no more groups
+// are started; the channel closes when those in progress are done.
+func analyzeGroups(ctx context.Context, a *analyzer.Analyzer, groups [][]database.Job, workers int) <-chan groupResult {
+	next := make(chan []database.Job)
+	finished := make(chan groupResult)
+
+	go func() {
+		defer close(next)
+		for _, group := range groups {
+			if ctx.Err() != nil {
+				return
+			}
+			select {
+			case next <- group:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for group := range next {
+				finished <- analyzeGroup(ctx, a, group)
+			}
+		}()
+	}
+	go func() {
+		wg.Wait()
+		close(finished)
+	}()
+	return finished
+}
+
+// analyzeGroup analyses a group's representative, or reuses the analysis of
+// a copy analysed earlier (e.g. by a previous run) unless --force is given.
+func analyzeGroup(ctx context.Context, a *analyzer.Analyzer, group []database.Job) groupResult {
+	job := group[dedup.Representative(group)]
+	res := groupResult{group: group}
+
+	if !analyzeForce {
+		source, err := analyzedClusterMember(&job)
+		if err != nil {
+			log.Printf("  Warning: %v", err)
+		}
+		if source != nil {
+			res.source, res.reused = source, true
+			return res
+		}
+	}
+
+	analysis, err := a.AnalyzeJob(ctx, &job)
+	if err != nil {
+		res.err = err
+		return res
+	}
+
+	// Update job with analysis results
+	now := time.Now()
+	job.MatchScore = analysis.MatchScore
+
+	// Store full formatted analysis (for display)
+	job.Analysis = fmt.Sprintf("Score: %d/100\n\nReasoning: %s\n\nPros:\n- %s\n\nCons:\n- %s",
+		analysis.MatchScore,
+		analysis.Reasoning,
+		joinStrings(analysis.Pros, "\n- "),
+		joinStrings(analysis.Cons, "\n- "),
+	)
+
+	// Store structured data (for querying)
+	job.AnalysisReasoning = analysis.Reasoning
+	prosJSON, _ := json.Marshal(analysis.Pros)
+	job.AnalysisPros = string(prosJSON)
+	consJSON, _ := json.Marshal(analysis.Cons)
+	job.AnalysisCons = string(consJSON)
+
+	// Record which resume was used (if any)
+	if a.UseResumes() {
+		job.ResumeUsed = a.GetResumeUsed(&job)
+	}
+
+	job.AnalysisModel = analysis.Model
+	job.IsAnalyzed = true
+	job.AnalyzedAt = &now
+	res.source, res.cached = &job, analysis.Cached
+	return res
+}
+
+// skipOutOfArea rejects on-site and hybrid jobs that are further than the
+// profile's max distance from all of its locations, without analysing them,
+// and returns the rest. They stay unanalysed, so a later run with other
+// locations reconsiders them. Jobs whose location is unknown, and jobs
+// analysed before (with --all-locations) that --force analyses again, are
+// kept.
+func skipOutOfArea(jobs []database.Job, prof *profile.Profile) []database.Job {
+	places := prof.PreferredPlaces()
+	if len(places) == 0 {
+		return jobs
+	}
+	maxKm := prof.MaxDistanceKm()
+
+	db := database.GetDB()
+	kept := jobs[:0]
+	skipped := 0
+	for _, job := range jobs {
+		place, ok := job.Place()
+		if !ok || job.WorkArrangement == classify.ArrangementRemote || job.IsAnalyzed {
+			kept = append(kept, job)
+			continue
+		}
+		nearest, km := places[0], geo.DistanceKm(place, places[0])
+		for _, p := range places[1:] {
+			if d := geo.DistanceKm(place, p); d < km {
+				nearest, km = p, d
+			}
+		}
+		if km <= maxKm {
+			kept = append(kept, job)
+			continue
+		}
+
+		reason := fmt.Sprintf("Not analysed: %s, %s is %.0f km from %s, the nearest of your locations", job.City, job.State, km, nearest.Name)
+		err := db.Model(&job).Updates(map[string]interface{}{"status": database.StatusRejected, "analysis_reasoning": reason}).Error
+		if err != nil {
+			log.Printf("Warning: failed to update job %d: %v", job.ID, err)
+		}
+		skipped++
+	}
+	if skipped > 0 {
+		fmt.Printf("Skipped %d jobs more than %.0f km from your locations (use --all-locations to analyse them)\n", skipped, maxKm)
+	}
+	return kept
+}
+
+// analyzedClusterMember returns an already analysed copy of job's role, or
+// nil if none has been analysed yet.
+func analyzedClusterMember(job *database.Job) (*database.Job, error) {
+	if job.ClusterID == 0 {
+		return nil, nil
+	}
+	var members []database.Job
+	err := database.GetDB().
+		Where("user_id = ? AND cluster_id = ? AND is_analyzed = ?", job.UserID, job.ClusterID, true).
+		Order("analyzed_at DESC").
+		Limit(1).
+		Find(&members).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to look up analysed copies: %w", err)
+	}
+	if len(members) == 0 {
+		return nil, nil
+	}
+	return &members[0], nil
+}
+
+// copyAnalysis gives dst the analysis of src, a copy of the same role.
+func copyAnalysis(dst, src *database.Job) {
+	dst.MatchScore = src.MatchScore
+	dst.Analysis = src.Analysis
+	dst.AnalysisReasoning = src.AnalysisReasoning
+	dst.AnalysisPros = src.AnalysisPros
+	dst.AnalysisCons = src.AnalysisCons
+	dst.ResumeUsed = src.ResumeUsed
+	dst.AnalysisModel = src.AnalysisModel
+	dst.IsAnalyzed = true
+	dst.AnalyzedAt = src.AnalyzedAt
+}
+
 // joinStrings joins a slice of strings with a separator
 func joinStrings(items []string, sep string) string {
 	if len(items) == 0 {
@@ -171,4 +437,7 @@ func init() {
 	// Add flags for filtering
 	analyzeCmd.Flags().BoolVar(&analyzeContractOnly, "contract", false, "Analyze only contract roles")
 	analyzeCmd.Flags().StringVarP(&analyzeJobType, "type", "t", "", "Analyze only specific job type (contract, permanent, unknown)")
+	analyzeCmd.Flags().BoolVar(&analyzeAllLocations, "all-locations", false, "Also analyze on-site and hybrid jobs far from your configured locations")
+	analyzeCmd.Flags().IntVarP(&analyzeConcurrency, "concurrency", "j", 1, "Roles to analyze at a time (each provider's requests_per_minute still applies)")
+	analyzeCmd.Flags().BoolVar(&analyzeForce, "force", false, "Analyze jobs again even if already analyzed (unchanged prompts are answered from the AI cache)")
 }
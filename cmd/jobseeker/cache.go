@@ -0,0 +1,89 @@
+package main
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/guidebee/jobseeker/internal/database"
+	"github.com/spf13/cobra"
+)
+
+var (
+	cachePurge        bool
+	cachePurgeExpired bool
+	cacheModel        string
+	cacheTemplate     string
+)
+
+var cacheCmd = &cobra.Command{
+	Use:   "cache",
+	Short: "Show or purge the cache of AI replies",
+	Long: `Shows the AI cache: the replies of each model to job analysis, job
+description analysis and keyword extraction prompts. analyze, checkjd,
+tailorcv, init, scan and keywords answer a prompt from the cache when the same
+model has answered it before, so re-running them costs nothing for prompts
+that haven't changed.
+
+Replies are used for AI_CACHE_TTL_DAYS (30 by default; 0 turns the cache
+off) and stay in the database until purged.
+
+Examples:
+  jobseeker cache
+  jobseeker cache --purge-expired
+  jobseeker cache --purge --model minimax
+  jobseeker cache --purge --template job-analysis`,
+	Run: runCache,
+}
+
+func runCache(cmd *cobra.Command, args []string) {
+	if _, err := initApp(); err != nil {
+		log.Fatalf("Initialization failed: %v", err)
+	}
+
+	if cachePurge || cachePurgeExpired {
+		n, err := database.PurgeAICache(database.AICachePurge{
+			ExpiredOnly: cachePurgeExpired && !cachePurge,
+			Model:       cacheModel,
+			Template:    cacheTemplate,
+		})
+		if err != nil {
+			log.Fatalf("%v", err)
+		}
+		fmt.Printf("✓ Purged %d cached AI replies\n\n", n)
+	} else if cacheModel != "" || cacheTemplate != "" {
+		log.Fatalf("--model and --template select what --purge or --purge-expired deletes")
+	}
+
+	stats, err := database.GetAICacheStats()
+	if err != nil {
+		log.Fatalf("%v", err)
+	}
+	if len(stats) == 0 {
+		fmt.Println("The AI cache is empty")
+		return
+	}
+
+	var entries, expired, hits, saved int
+	fmt.Println("Cached AI replies:")
+	fmt.Println()
+	for _, s := range stats {
+		fmt.Printf("%s  %s\n", s.Model, s.Template)
+		fmt.Printf("     %d replies (%d expired) | %d tokens in, %d out | %d reuses saved %d tokens\n",
+			s.Entries, s.Expired, s.InputTokens, s.OutputTokens, s.Hits, s.TokensSaved)
+		entries += s.Entries
+		expired += s.Expired
+		hits += s.Hits
+		saved += s.TokensSaved
+	}
+	fmt.Printf("\nTotal: %d replies (%d expired) | %d reuses | %d tokens saved\n", entries, expired, hits, saved)
+	if expired > 0 {
+		fmt.Println("Run 'jobseeker cache --purge-expired' to delete the expired replies")
+	}
+}
+
+func init() {
+	cacheCmd.Flags().BoolVar(&cachePurge, "purge", false, "Delete cached replies (all, or those --model and --template select)")
+	cacheCmd.Flags().BoolVar(&cachePurgeExpired, "purge-expired", false, "Delete cached replies older than AI_CACHE_TTL_DAYS")
+	cacheCmd.Flags().StringVar(&cacheModel, "model", "", "Only purge replies of this provider or provider/model")
+	cacheCmd.Flags().StringVar(&cacheTemplate, "template", "", "Only purge replies to this prompt template, e.g. job-analysis or job-analysis/v1")
+}
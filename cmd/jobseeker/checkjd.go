@@ -10,6 +10,7 @@ import (
 
 	"github.com/guidebee/jobseeker/internal/jd"
 	"github.com/guidebee/jobseeker/internal/resume"
+	"github.com/guidebee/jobseeker/pkg/llm"
 	"github.com/spf13/cobra"
 )
 
@@ -46,10 +47,10 @@ func runCheckJD(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	// Get Claude API key
-	apiKey := os.Getenv("CLAUDE_API_KEY")
-	if apiKey == "" {
-		return fmt.Errorf("CLAUDE_API_KEY environment variable not set")
+	// JD analysis uses the AI provider under ai.jd_analysis in the config (Claude by default)
+	provider, err := newProvider(prof.AI, llm.TaskJDAnalysis)
+	if err != nil {
+		return err
 	}
 
 	// Load resumes
@@ -77,7 +78,7 @@ func runCheckJD(cmd *cobra.Command, args []string) error {
 	log.Printf("Found %d job description(s) to process\n", len(jds))
 
 	// Create analyzer
-	analyzer := jd.NewJDAnalyzer(apiKey, prof, resumes)
+	analyzer := jd.NewJDAnalyzer(provider, prof, resumes)
 
 	// Create cover letters directory if needed
 	if err := os.MkdirAll(coverDir, 0755); err != nil {
@@ -91,7 +92,7 @@ func runCheckJD(cmd *cobra.Command, args []string) error {
 		fmt.Printf("%s\n\n", strings.Repeat("=", 80))
 
 		// Analyze the job description
-		fmt.Println("Analyzing job description with Claude AI...")
+		fmt.Println("Analyzing job description with AI...")
 		result, err := analyzer.AnalyzeJobDescription(jobDesc)
 		if err != nil {
 			log.Printf("Error analyzing %s: %v", jobDesc.Filename, err)
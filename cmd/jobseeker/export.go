@@ -21,6 +21,9 @@ var (
 	exportMaxResults      int
 	exportJobType         string
 	exportSource          string
+	exportMinSalary       int
+	exportMeetsMinimum    bool
+	exportSort            string
 )
 
 var exportCmd = &cobra.Command{
@@ -39,7 +42,8 @@ clickable URLs, and auto-filtering on all columns.
 Example: jobseeker export
 Example: jobseeker export --recommended --output my_jobs.xlsx
 Example: jobseeker export --min-score 70 --max-results 50
-Example: jobseeker export --all-statuses --job-type contract`,
+Example: jobseeker export --all-statuses --job-type contract
+Example: jobseeker export --meets-minimum --sort salary`,
 	RunE: runExport,
 }
 
@@ -54,6 +58,9 @@ func init() {
 	exportCmd.Flags().IntVar(&exportMaxResults, "max-results", 0, "Maximum number of jobs to export (0 = unlimited)")
 	exportCmd.Flags().StringVar(&exportJobType, "job-type", "", "Filter by job type: contract, permanent")
 	exportCmd.Flags().StringVar(&exportSource, "source", "", "Filter by source: seek, linkedin, indeed")
+	exportCmd.Flags().IntVar(&exportMinSalary, "min-salary", 0, "Minimum pay a year (day and hourly rates are annualised)")
+	exportCmd.Flags().BoolVar(&exportMeetsMinimum, "meets-minimum", false, "Only jobs paying at least the salary or contract rate minimum in config.yaml")
+	exportCmd.Flags().StringVar(&exportSort, "sort", "date", "Sort by date (found), fresh (listed), salary (annualised) or score")
 }
 
 func runExport(cmd *cobra.Command, args []string) error {
@@ -89,9 +96,15 @@ func runExport(cmd *cobra.Command, args []string) error {
 		query = query.Where("source = ?", exportSource)
 	}
 
+	query = filterByPay(query, prof, exportMinSalary, exportMeetsMinimum)
+	query, err = orderJobs(query, exportSort)
+	if err != nil {
+		return err
+	}
+
 	// Get jobs
 	var jobs []database.Job
-	err = query.Order("created_at DESC").Find(&jobs).Error
+	err = query.Find(&jobs).Error
 	if err != nil {
 		return fmt.Errorf("failed to fetch jobs: %w", err)
 	}
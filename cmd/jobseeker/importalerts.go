@@ -0,0 +1,99 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"strconv"
+
+	"github.com/guidebee/jobseeker/internal/database"
+	"github.com/guidebee/jobseeker/internal/scraper"
+	"github.com/spf13/cobra"
+)
+
+var importAlertsNoFetch bool
+
+var importAlertsCmd = &cobra.Command{
+	Use:   "import-alerts <path>",
+	Short: "Import jobs from saved SEEK, LinkedIn and Indeed alert emails",
+	Long: `Reads job alert emails saved as an mbox file, a single .eml file or a
+directory of .eml files, and saves every SEEK, LinkedIn and Indeed job they
+link to. Jobs that are already stored are skipped, so the same mailbox can be
+imported again safely.
+
+New jobs have their descriptions fetched from the job board, as scan does,
+unless --no-fetch is given.
+
+Examples:
+  jobseeker import-alerts ~/mail/job-alerts.mbox
+  jobseeker import-alerts ./alerts/`,
+	Args: cobra.ExactArgs(1),
+	Run:  runImportAlerts,
+}
+
+func runImportAlerts(cmd *cobra.Command, args []string) {
+	prof, err := initApp()
+	if err != nil {
+		log.Fatalf("Initialization failed: %v", err)
+	}
+
+	user, err := database.GetCurrentUser()
+	if err != nil {
+		log.Fatalf("Failed to get current user: %v\nRun 'jobseeker init' first", err)
+	}
+
+	jobs, err := scraper.ParseAlerts(args[0])
+	if err != nil {
+		log.Fatalf("Failed to read alerts: %v", err)
+	}
+	fmt.Printf("Found %d job links in %s\n", len(jobs), args[0])
+
+	// Only jobs we haven't stored yet are fetched and saved
+	var newJobs []*database.Job
+	for _, job := range jobs {
+		if !scraper.JobExists(job.ExternalID, user.ID) {
+			newJobs = append(newJobs, job)
+		}
+	}
+	fmt.Printf("  %d new, %d already stored\n", len(newJobs), len(jobs)-len(newJobs))
+
+	if !importAlertsNoFetch && len(newJobs) > 0 {
+		delayMs, _ := strconv.Atoi(getEnv("SCRAPER_DELAY_MS", "2000"))
+		s := scraper.NewScraper(delayMs)
+		boards := make(map[string]scraper.Board)
+
+		fmt.Println("\nFetching job details...")
+		for i, job := range newJobs {
+			board, ok := boards[job.Source]
+			if !ok {
+				if board, err = scraper.NewBoard(job.Source, s, prof.JobBoards[job.Source]); err != nil {
+					log.Printf("  Skipping details for %s jobs: %v", job.Source, err)
+				}
+				boards[job.Source] = board
+			}
+			if board == nil {
+				continue
+			}
+			fmt.Printf("[%d/%d] %s\n", i+1, len(newJobs), job.URL)
+			if err := board.FetchDetail(job); err != nil {
+				log.Printf("  Could not fetch details for %s: %v", job.URL, err)
+			}
+		}
+	}
+
+	stats, err := scraper.SaveJobs(newJobs, user.ID)
+	if err != nil {
+		log.Fatalf("Failed to save jobs: %v", err)
+	}
+
+	fmt.Printf("\n✓ Imported %d new jobs\n", stats.New)
+	if stats.Failed > 0 {
+		fmt.Printf("  %d jobs could not be saved (see log)\n", stats.Failed)
+	}
+	if stats.New > 0 {
+		fmt.Println("Run 'jobseeker analyze' to evaluate new jobs with AI")
+	}
+}
+
+func init() {
+	importAlertsCmd.Flags().BoolVar(&importAlertsNoFetch, "no-fetch", false, "Save jobs from the email links only, without fetching descriptions")
+}
@@ -10,9 +10,9 @@ import (
 	"github.com/guidebee/jobseeker/internal/database"
 	"github.com/guidebee/jobseeker/internal/resume"
 	"github.com/guidebee/jobseeker/pkg/browser"
-	"github.com/guidebee/jobseeker/pkg/claude"
 	"github.com/guidebee/jobseeker/pkg/github"
 	linkedinpkg "github.com/guidebee/jobseeker/pkg/linkedin"
+	"github.com/guidebee/jobseeker/pkg/llm"
 	"github.com/spf13/cobra"
 )
 
@@ -73,7 +73,6 @@ func runInit(cmd *cobra.Command, args []string) {
 	fmt.Println("\nLoading profile data...")
 
 	// Load resumes
-	resumesDir := "./resumes"
 	resumes, err := resume.LoadResumes(resumesDir)
 	if err != nil {
 		log.Printf("Warning: No resumes found (%v)", err)
@@ -91,23 +90,17 @@ func runInit(cmd *cobra.Command, args []string) {
 		log.Fatalf("Failed to marshal resumes: %v", err)
 	}
 
-	// Extract keywords from resumes
-	var keywordsJSON string
+	// Extract keywords from all resumes; scan reuses them until the resumes change
+	var keywordsJSON, resumesHash string
 	if len(resumes) > 0 {
-		apiKey := os.Getenv("CLAUDE_API_KEY")
-		if apiKey != "" {
-			fmt.Println("\nExtracting keywords from resume...")
-			claudeClient := claude.NewClient(apiKey)
-			keywords, err := resume.ExtractKeywords(resumes[0], claudeClient)
-			if err != nil {
-				log.Printf("Warning: Failed to extract keywords: %v", err)
-			} else {
-				fmt.Printf("✓ Extracted keywords:\n")
-				fmt.Printf("  Primary skills: %v\n", keywords.PrimarySkills)
-				fmt.Printf("  Roles: %v\n", keywords.Roles)
-				keywordsData, _ := json.Marshal(keywords)
-				keywordsJSON = string(keywordsData)
-			}
+		fmt.Println("\nExtracting keywords from resumes...")
+		keywords, err := extractKeywords(prof.AI, resumes, false)
+		if err != nil {
+			log.Printf("Warning: %v", err)
+		} else {
+			keywordsData, _ := json.Marshal(keywords)
+			keywordsJSON = string(keywordsData)
+			resumesHash = resume.ResumesHash(resumes)
 		}
 	}
 
@@ -162,13 +155,11 @@ func runInit(cmd *cobra.Command, args []string) {
 			}
 			// If no resumes were loaded, extract keywords from the LinkedIn profile too
 			if len(resumes) == 0 && keywordsJSON == "" {
-				apiKey := os.Getenv("CLAUDE_API_KEY")
-				if apiKey != "" {
+				if provider, err := newProvider(prof.AI, llm.TaskKeywords); err == nil {
 					fmt.Println("  Extracting keywords from LinkedIn profile...")
-					claudeClient := claude.NewClient(apiKey)
 					virtualResume := resume.LoadLinkedInAsResume(linkedinProfileText)
 					if virtualResume != nil {
-						keywords, err := resume.ExtractKeywords(virtualResume, claudeClient)
+						keywords, err := resume.ExtractKeywords(virtualResume, provider)
 						if err != nil {
 							log.Printf("Warning: Failed to extract keywords from LinkedIn: %v", err)
 						} else {
@@ -191,6 +182,7 @@ func runInit(cmd *cobra.Command, args []string) {
 		LinkedInProfile: linkedinProfileText,
 		LinkedInURL:     linkedinURL,
 		SearchKeywords:  keywordsJSON,
+		ResumesHash:     resumesHash,
 		ResumesCount:    len(resumes),
 		LastInitAt:      time.Now(),
 		InitVersion:     "1.0",
@@ -0,0 +1,239 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"slices"
+	"strings"
+
+	"github.com/guidebee/jobseeker/internal/database"
+	"github.com/guidebee/jobseeker/internal/resume"
+	"github.com/guidebee/jobseeker/pkg/llm"
+	"github.com/spf13/cobra"
+)
+
+// resumesDir is where init, scan and keywords look for .docx resumes.
+const resumesDir = "./resumes"
+
+var (
+	keywordsAdd     []string
+	keywordsAddRole []string
+	keywordsRemove  []string
+	keywordsRefresh bool
+)
+
+var keywordsCmd = &cobra.Command{
+	Use:   "keywords",
+	Short: "Show or edit the search keywords taken from your resumes",
+	Long: `Shows the search keywords and roles extracted from your resumes, which scan
+turns into SEEK, LinkedIn and Indeed searches.
+
+Keywords are extracted once and cached; scan extracts them again only when a
+resume in ./resumes is added, removed or edited. Edits made here are kept
+until then. --refresh asks the AI provider again even for unchanged resumes,
+bypassing the AI cache.
+
+Examples:
+  jobseeker keywords
+  jobseeker keywords --add "platform engineer" --remove "php developer"
+  jobseeker keywords --add-role "Staff Engineer"
+  jobseeker keywords --refresh`,
+	Run: runKeywords,
+}
+
+func runKeywords(cmd *cobra.Command, args []string) {
+	prof, err := initApp()
+	if err != nil {
+		log.Fatalf("Initialization failed: %v", err)
+	}
+
+	user, err := database.GetCurrentUser()
+	if err != nil {
+		log.Fatalf("Failed to get current user: %v\nRun 'jobseeker init' first", err)
+	}
+
+	data, err := database.GetProfileData(user.ID)
+	if err != nil {
+		log.Fatalf("%v", err)
+	}
+	keywords, hash := storedKeywords(data), ""
+	if data != nil {
+		hash = data.ResumesHash
+	}
+
+	if keywordsRefresh {
+		resumes, err := resume.LoadResumes(resumesDir)
+		if err != nil || len(resumes) == 0 {
+			log.Fatalf("No resumes to extract keywords from in %s", resumesDir)
+		}
+		if keywords, err = extractKeywords(prof.AI, resumes, true); err != nil {
+			log.Fatalf("%v", err)
+		}
+		hash = resume.ResumesHash(resumes)
+		if err := saveKeywords(user.ID, keywords, hash); err != nil {
+			log.Fatalf("%v", err)
+		}
+	}
+
+	if len(keywordsAdd)+len(keywordsAddRole)+len(keywordsRemove) > 0 {
+		if keywords == nil {
+			keywords = &resume.KeywordExtraction{}
+		}
+		keywords.SearchKeywords = editList(keywords.SearchKeywords, keywordsAdd, keywordsRemove)
+		keywords.Roles = editList(keywords.Roles, keywordsAddRole, keywordsRemove)
+		// Keep the hash, so scan keeps these edits until the resumes change
+		if err := saveKeywords(user.ID, keywords, hash); err != nil {
+			log.Fatalf("%v", err)
+		}
+		fmt.Println("✓ Search keywords updated")
+	}
+
+	if keywords == nil {
+		fmt.Println("No search keywords stored yet.")
+		fmt.Println("Add .docx resumes to ./resumes and run 'jobseeker keywords --refresh' (needs CLAUDE_API_KEY, or the key of the provider under ai.keywords)")
+		return
+	}
+
+	fmt.Printf("Search keywords (%d):\n", len(keywords.SearchKeywords))
+	for _, k := range keywords.SearchKeywords {
+		fmt.Printf("  - %s\n", k)
+	}
+	fmt.Printf("Roles (%d):\n", len(keywords.Roles))
+	for _, r := range keywords.Roles {
+		fmt.Printf("  - %s\n", r)
+	}
+	for _, list := range []struct {
+		name  string
+		items []string
+	}{
+		{"Primary skills", keywords.PrimarySkills},
+		{"Secondary skills", keywords.SecondarySkills},
+		{"Industries", keywords.Industries},
+		{"Certifications", keywords.Certifications},
+	} {
+		if len(list.items) > 0 {
+			fmt.Printf("%s: %s\n", list.name, strings.Join(list.items, ", "))
+		}
+	}
+
+	if resumes, err := resume.LoadResumes(resumesDir); err == nil && len(resumes) > 0 && resume.ResumesHash(resumes) != hash {
+		fmt.Println("\nYour resumes have changed since these keywords were extracted; the next scan extracts them again.")
+	}
+}
+
+// editList removes the entries named in remove (ignoring case) from list and
+// appends those in add that it doesn't already hold.
+func editList(list, add, remove []string) []string {
+	var out []string
+	for _, item := range list {
+		if !slices.ContainsFunc(remove, func(r string) bool { return strings.EqualFold(strings.TrimSpace(r), item) }) {
+			out = append(out, item)
+		}
+	}
+	for _, item := range add {
+		item = strings.TrimSpace(item)
+		if item != "" && !slices.ContainsFunc(out, func(o string) bool { return strings.EqualFold(o, item) }) {
+			out = append(out, item)
+		}
+	}
+	return out
+}
+
+// loadSearchKeywords returns the search keywords for scan: the cached ones
+// while the resumes are unchanged, otherwise keywords extracted afresh from
+// every resume (and cached). It falls back to the cached keywords if they
+// can't be extracted, and returns nil if there are none.
+func loadSearchKeywords(settings llm.Settings, userID uint) *resume.KeywordExtraction {
+	data, err := database.GetProfileData(userID)
+	if err != nil {
+		log.Printf("Warning: %v", err)
+	}
+	cached := storedKeywords(data)
+
+	resumes, err := resume.LoadResumes(resumesDir)
+	if err != nil || len(resumes) == 0 {
+		// Keywords init took from the LinkedIn profile, if any
+		return cached
+	}
+
+	hash := resume.ResumesHash(resumes)
+	if cached != nil && data.ResumesHash == hash {
+		fmt.Println("✓ Using cached search keywords ('jobseeker keywords' to review them)")
+		return cached
+	}
+
+	fmt.Printf("✓ Found %d new or changed resume(s), generating search keywords...\n", len(resumes))
+	keywords, err := extractKeywords(settings, resumes, false)
+	if err != nil {
+		log.Printf("Warning: %v", err)
+		if cached != nil {
+			fmt.Println("  Using the previously extracted keywords")
+		}
+		return cached
+	}
+	if err := saveKeywords(userID, keywords, hash); err != nil {
+		log.Printf("Warning: %v", err)
+	}
+	return keywords
+}
+
+// extractKeywords asks the keywords provider (Claude by default) for the
+// search keywords of every resume and merges them. With fresh, the AI cache
+// is left out, so unchanged resumes are sent again.
+func extractKeywords(settings llm.Settings, resumes []*resume.Resume, fresh bool) (*resume.KeywordExtraction, error) {
+	var provider llm.Provider
+	var err error
+	if fresh {
+		provider, err = settings.New(llm.TaskKeywords)
+	} else {
+		provider, err = newProvider(settings, llm.TaskKeywords)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("can't extract keywords from resumes: %w", err)
+	}
+
+	for _, r := range resumes {
+		fmt.Printf("  Analyzing: %s\n", r.Filename)
+	}
+	keywords, err := resume.ExtractAllKeywords(resumes, provider)
+	if err != nil {
+		return nil, fmt.Errorf("failed to extract keywords: %w", err)
+	}
+
+	fmt.Printf("  Extracted keywords:\n")
+	fmt.Printf("    Primary skills: %v\n", keywords.PrimarySkills)
+	fmt.Printf("    Roles: %v\n", keywords.Roles)
+	fmt.Printf("    Search terms: %d\n", len(keywords.SearchKeywords))
+	return keywords, nil
+}
+
+// storedKeywords decodes the keywords cached in the profile data, or returns
+// nil if there are none.
+func storedKeywords(data *database.ProfileData) *resume.KeywordExtraction {
+	if data == nil || data.SearchKeywords == "" {
+		return nil
+	}
+	var keywords resume.KeywordExtraction
+	if err := json.Unmarshal([]byte(data.SearchKeywords), &keywords); err != nil {
+		log.Printf("Warning: ignoring unreadable cached keywords: %v", err)
+		return nil
+	}
+	return &keywords
+}
+
+// saveKeywords caches keywords with the hash of the resumes they match.
+func saveKeywords(userID uint, keywords *resume.KeywordExtraction, resumesHash string) error {
+	data, err := json.Marshal(keywords)
+	if err != nil {
+		return fmt.Errorf("failed to encode keywords: %w", err)
+	}
+	return database.SaveSearchKeywords(userID, string(data), resumesHash)
+}
+
+func init() {
+	keywordsCmd.Flags().StringArrayVar(&keywordsAdd, "add", nil, "Add a search keyword (repeatable)")
+	keywordsCmd.Flags().StringArrayVar(&keywordsAddRole, "add-role", nil, "Add a role to search for (repeatable)")
+	keywordsCmd.Flags().StringArrayVar(&keywordsRemove, "remove", nil, "Remove a search keyword or role (repeatable)")
+	keywordsCmd.Flags().BoolVar(&keywordsRefresh, "refresh", false, "Extract keywords from the resumes again now, without the AI cache")
+}
@@ -9,8 +9,8 @@ import (
 	"time"
 
 	"github.com/guidebee/jobseeker/pkg/browser"
-	claudepkg "github.com/guidebee/jobseeker/pkg/claude"
 	linkedinpkg "github.com/guidebee/jobseeker/pkg/linkedin"
+	"github.com/guidebee/jobseeker/pkg/llm"
 	puppeteerpkg "github.com/guidebee/jobseeker/pkg/puppeteer"
 	"github.com/spf13/cobra"
 )
@@ -91,10 +91,9 @@ func runLinkedIn(cmd *cobra.Command, args []string) {
 		}
 	}
 
-	if apiKey := os.Getenv("CLAUDE_API_KEY"); apiKey != "" {
-		fmt.Println("Inferring skills via Claude AI...")
-		client := claudepkg.NewClient(apiKey)
-		if err := profile.InferSkills(client.SendMessage); err != nil {
+	if provider, err := aiSettings().New(llm.TaskKeywords); err == nil {
+		fmt.Println("Inferring skills via AI...")
+		if err := profile.InferSkills(provider.SendMessage); err != nil {
 			log.Printf("Warning: could not infer skills: %v", err)
 		}
 	}
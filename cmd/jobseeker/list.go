@@ -3,8 +3,13 @@ package main
 import (
 	"fmt"
 	"log"
+	"strings"
+	"time"
 
+	"github.com/guidebee/jobseeker/internal/classify"
 	"github.com/guidebee/jobseeker/internal/database"
+	"github.com/guidebee/jobseeker/internal/geo"
+	"github.com/guidebee/jobseeker/internal/salary"
 	"github.com/spf13/cobra"
 )
 
@@ -13,6 +18,15 @@ var (
 	jobTypeFilter    string
 	showRecommended  bool
 	showContractOnly bool
+	showAllCopies    bool
+	listMinSalary    int
+	listMeetsMinimum bool
+	listSort         string
+	listArrangement  string
+	listSeniority    string
+	listSchedule     string
+	listViable       bool
+	listWithin       string
 	limit            int
 )
 
@@ -25,7 +39,7 @@ var listCmd = &cobra.Command{
 
 func runList(cmd *cobra.Command, args []string) {
 	// Initialize app
-	_, err := initApp()
+	prof, err := initApp()
 	if err != nil {
 		log.Fatalf("Initialization failed: %v", err)
 	}
@@ -40,7 +54,10 @@ func runList(cmd *cobra.Command, args []string) {
 	var jobs []database.Job
 
 	// Build query with user filter
-	query := db.Where("user_id = ?", user.ID).Order("created_at DESC")
+	query, err := orderJobs(db.Where("user_id = ?", user.ID), listSort)
+	if err != nil {
+		log.Fatalf("%v", err)
+	}
 
 	if showRecommended {
 		query = query.Where("status = ?", "recommended")
@@ -55,7 +72,48 @@ func runList(cmd *cobra.Command, args []string) {
 		query = query.Where("job_type = ?", jobTypeFilter)
 	}
 
-	if limit > 0 {
+	// Filter by classification
+	if listArrangement != "" {
+		query = query.Where("work_arrangement = ?", listArrangement)
+	}
+	if listSeniority != "" {
+		query = query.Where("seniority = ?", listSeniority)
+	}
+	if listSchedule != "" {
+		query = query.Where("schedule = ?", listSchedule)
+	}
+	if listViable {
+		eligibility := prof.Eligibility
+		if !eligibility.Known() {
+			log.Fatalf("--viable needs your work rights: set eligibility.australian_citizen and eligibility.clearance in config.yaml")
+		}
+		query = query.Where("clearance IN ?", classify.ClearancesUpTo(eligibility.Clearance))
+		if eligibility.AustralianCitizen != nil && !*eligibility.AustralianCitizen {
+			query = query.Where("citizenship_required = ?", false)
+		}
+	}
+
+	// Filter by annualised pay
+	query = filterByPay(query, prof, listMinSalary, listMeetsMinimum)
+
+	// Show one listing per role unless asked for every copy
+	if !showAllCopies {
+		query = query.Where("cluster_id = 0 OR cluster_id = id")
+	}
+
+	// Distance is worked out per job, so the limit is applied afterwards
+	var within float64
+	var home geo.Place
+	if listWithin != "" {
+		if within, err = geo.ParseDistance(listWithin); err != nil {
+			log.Fatalf("%v", err)
+		}
+		var ok bool
+		if home, ok = geo.Lookup(prof.Profile.Location); !ok {
+			log.Fatalf("Your location %q is not a known Australian place; set profile.location in config.yaml", prof.Profile.Location)
+		}
+		query = query.Where("work_arrangement = ? OR city <> ''", classify.ArrangementRemote)
+	} else if limit > 0 {
 		query = query.Limit(limit)
 	}
 
@@ -65,25 +123,71 @@ func runList(cmd *cobra.Command, args []string) {
 		log.Fatalf("Failed to fetch jobs: %v", result.Error)
 	}
 
+	if within > 0 {
+		jobs = jobsWithin(jobs, home, within)
+		if limit > 0 && len(jobs) > limit {
+			jobs = jobs[:limit]
+		}
+	}
+
 	// Display results
 	if len(jobs) == 0 {
 		fmt.Println("No jobs found")
 		return
 	}
 
+	ids := make([]uint, len(jobs))
+	for i, job := range jobs {
+		ids[i] = job.ID
+	}
+	revisions, err := database.GetRevisions(ids)
+	if err != nil {
+		log.Printf("Warning: %v", err)
+	}
+	var copies map[uint][]database.Job
+	if !showAllCopies {
+		if copies, err = clusterCopies(user.ID, ids); err != nil {
+			log.Printf("Warning: %v", err)
+		}
+	}
+
 	fmt.Printf("Found %d jobs:\n\n", len(jobs))
 
 	for i, job := range jobs {
-		fmt.Printf("%d. %s\n", i+1, job.Title)
+		fmt.Printf("%d. %s", i+1, job.Title)
+		if badges := jobBadges(&job, revisions[job.ID]); len(badges) > 0 {
+			fmt.Printf("  [%s]", strings.Join(badges, "] ["))
+		}
+		fmt.Println()
 		fmt.Printf("   Company: %s | Location: %s | Type: %s\n", job.Company, job.Location, job.JobType)
+		if role := roleSummary(&job); role != "" {
+			fmt.Printf("   Role: %s\n", role)
+		}
+		if listing := listingSummary(&job, time.Now()); listing != "" {
+			fmt.Printf("   Listed: %s\n", listing)
+		}
 		if job.Salary != "" {
-			fmt.Printf("   Rate/Salary: %s\n", job.Salary)
+			fmt.Printf("   Rate/Salary: %s", job.Salary)
+			if note := annualPayNote(&job); note != "" {
+				fmt.Printf(" (%s)", note)
+			}
+			fmt.Println()
 		}
 		fmt.Printf("   Status: %s", job.Status)
 		if job.IsAnalyzed {
 			fmt.Printf(" | Match Score: %d/100", job.MatchScore)
+			if job.AnalysisModel != "" {
+				fmt.Printf(" (%s)", job.AnalysisModel)
+			}
 		}
 		fmt.Printf("\n   URL: %s\n", job.URL)
+		if others := copies[job.ID]; len(others) > 0 {
+			fmt.Printf("   Also listed %d more times:", len(others))
+			for _, other := range others {
+				fmt.Printf(" %s (%s)", other.Source, other.Company)
+			}
+			fmt.Println()
+		}
 
 		if job.IsAnalyzed && job.Analysis != "" {
 			fmt.Printf("   Analysis:\n")
@@ -100,11 +204,155 @@ func runList(cmd *cobra.Command, args []string) {
 	fmt.Printf("Total: %d jobs\n", len(jobs))
 }
 
+// jobsWithin keeps remote jobs and jobs within km of home.
+func jobsWithin(jobs []database.Job, home geo.Place, km float64) []database.Job {
+	kept := jobs[:0]
+	for _, job := range jobs {
+		place, ok := job.Place()
+		if job.WorkArrangement == classify.ArrangementRemote || (ok && geo.DistanceKm(home, place) <= km) {
+			kept = append(kept, job)
+		}
+	}
+	return kept
+}
+
+// roleSummary describes a job's classification, e.g. "hybrid, senior,
+// full-time | needs NV1 clearance".
+func roleSummary(job *database.Job) string {
+	var parts []string
+	for _, p := range []string{job.WorkArrangement, job.Seniority, job.Schedule} {
+		if p != "" {
+			parts = append(parts, p)
+		}
+	}
+	summary := strings.Join(parts, ", ")
+
+	needs := ""
+	switch {
+	case job.Clearance != "":
+		needs = "needs " + strings.ToUpper(job.Clearance) + " clearance"
+	case job.CitizenshipRequired:
+		needs = "Australian citizens only"
+	}
+	if needs != "" && summary != "" {
+		return summary + " | " + needs
+	}
+	return summary + needs
+}
+
+// listingSummary describes how long ago the board listed a job and how many
+// people have applied, e.g. "3 days ago, 40 applicants", or "" if unknown.
+func listingSummary(job *database.Job, now time.Time) string {
+	var parts []string
+	if job.ListedAt != nil {
+		switch age := now.Sub(*job.ListedAt); {
+		case age < time.Hour:
+			parts = append(parts, "just now")
+		case age < 24*time.Hour:
+			parts = append(parts, ago(int(age.Hours()), "hour"))
+		default:
+			parts = append(parts, ago(int(age.Hours()/24), "day"))
+		}
+	}
+	if job.Applicants != nil {
+		if *job.Applicants == 0 {
+			parts = append(parts, "few applicants yet")
+		} else {
+			parts = append(parts, fmt.Sprintf("%d applicants", *job.Applicants))
+		}
+	}
+	return strings.Join(parts, ", ")
+}
+
+// ago formats an age such as "1 day ago" or "3 days ago".
+func ago(n int, unit string) string {
+	if n != 1 {
+		unit += "s"
+	}
+	return fmt.Sprintf("%d %s ago", n, unit)
+}
+
+// clusterCopies returns the other listings of each given job's role, keyed
+// by the ID of the job they are a copy of.
+func clusterCopies(userID uint, jobIDs []uint) (map[uint][]database.Job, error) {
+	copies := make(map[uint][]database.Job)
+	if len(jobIDs) == 0 {
+		return copies, nil
+	}
+
+	var jobs []database.Job
+	err := database.GetDB().
+		Select("id", "cluster_id", "source", "company").
+		Where("user_id = ? AND cluster_id IN ? AND cluster_id <> id", userID, jobIDs).
+		Order("id").
+		Find(&jobs).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to load copies of jobs: %w", err)
+	}
+	for _, job := range jobs {
+		copies[job.ClusterID] = append(copies[job.ClusterID], job)
+	}
+	return copies, nil
+}
+
+// jobBadges describes what changed about a job since it was first stored,
+// from its revisions (oldest first).
+func jobBadges(job *database.Job, revs []database.JobRevision) []string {
+	var badges []string
+	if job.IsExpired() {
+		badges = append(badges, "closed")
+	}
+
+	latest := make(map[string]database.JobRevision)
+	for _, rev := range revs {
+		latest[rev.Field] = rev
+	}
+	if rev, ok := latest["listing"]; ok && rev.NewValue == database.ListingReposted && !job.IsExpired() {
+		badges = append(badges, "re-posted")
+	}
+	if rev, ok := latest["salary"]; ok {
+		oldPay, newPay := annualPay(rev.OldValue), annualPay(rev.NewValue)
+		switch {
+		case oldPay > 0 && newPay > oldPay:
+			badges = append(badges, "salary increased")
+		case newPay > 0 && newPay < oldPay:
+			badges = append(badges, "salary decreased")
+		default:
+			badges = append(badges, "salary changed")
+		}
+	}
+	for _, field := range []string{"title", "company", "location"} {
+		if _, ok := latest[field]; ok {
+			badges = append(badges, field+" changed")
+		}
+	}
+	return badges
+}
+
+// annualPay returns the yearly equivalent of a salary text, or 0 if it
+// can't be parsed.
+func annualPay(text string) float64 {
+	pay, ok := salary.Parse(text)
+	if !ok {
+		return 0
+	}
+	return pay.Annual()
+}
+
 func init() {
 	// Add flags for filtering
 	listCmd.Flags().StringVarP(&statusFilter, "status", "s", "", "Filter by status (discovered, recommended, applied, rejected)")
 	listCmd.Flags().StringVarP(&jobTypeFilter, "type", "t", "", "Filter by job type (contract, permanent, unknown)")
 	listCmd.Flags().BoolVarP(&showRecommended, "recommended", "r", false, "Show only recommended jobs")
 	listCmd.Flags().BoolVar(&showContractOnly, "contract", false, "Show only contract roles")
+	listCmd.Flags().BoolVar(&showAllCopies, "all-copies", false, "Show every listing of a role, not just the first")
+	listCmd.Flags().IntVar(&listMinSalary, "min-salary", 0, "Show only jobs paying at least this much a year (day and hourly rates are annualised)")
+	listCmd.Flags().BoolVar(&listMeetsMinimum, "meets-minimum", false, "Show only jobs paying at least the salary or contract rate minimum in config.yaml")
+	listCmd.Flags().StringVar(&listSort, "sort", "date", "Sort by date (found), fresh (listed), salary (annualised) or score")
+	listCmd.Flags().StringVar(&listArrangement, "arrangement", "", "Filter by work arrangement (remote, hybrid, onsite)")
+	listCmd.Flags().StringVar(&listSeniority, "seniority", "", "Filter by seniority (graduate, junior, mid, senior, lead, principal, executive)")
+	listCmd.Flags().StringVar(&listSchedule, "schedule", "", "Filter by schedule (full-time, part-time, casual)")
+	listCmd.Flags().BoolVar(&listViable, "viable", false, "Hide roles needing citizenship or a clearance you don't have (eligibility in config.yaml)")
+	listCmd.Flags().StringVar(&listWithin, "within", "", "Show only remote jobs and jobs within this distance of your location, e.g. 25km")
 	listCmd.Flags().IntVarP(&limit, "limit", "l", 10, "Maximum number of jobs to show")
 }
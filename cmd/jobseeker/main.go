@@ -4,9 +4,12 @@ import (
 	"fmt"
 	"log"
 	"os"
+	"strconv"
+	"time"
 
 	"github.com/guidebee/jobseeker/internal/database"
 	"github.com/guidebee/jobseeker/internal/profile"
+	"github.com/guidebee/jobseeker/pkg/llm"
 	"github.com/joho/godotenv"
 	"github.com/spf13/cobra"
 )
@@ -20,7 +23,7 @@ var (
 var rootCmd = &cobra.Command{
 	Use:   "jobseeker",
 	Short: "AI-powered job application assistant",
-	Long: `Jobseeker automatically discovers jobs, analyzes matches using Claude AI,
+	Long: `Jobseeker automatically discovers jobs, analyzes matches using AI,
 and helps you apply to the best opportunities.`,
 }
 
@@ -51,6 +54,10 @@ func init() {
 	rootCmd.AddCommand(checkjdCmd)
 	rootCmd.AddCommand(tailorcvCmd)
 	rootCmd.AddCommand(exportCmd)
+	rootCmd.AddCommand(importAlertsCmd)
+	rootCmd.AddCommand(scansCmd)
+	rootCmd.AddCommand(keywordsCmd)
+	rootCmd.AddCommand(cacheCmd)
 }
 
 // initApp initializes database and profile
@@ -71,6 +78,43 @@ func initApp() (*profile.Profile, error) {
 	return prof, nil
 }
 
+// aiSettings returns the ai: settings of the config for commands that don't
+// otherwise need the profile, or the defaults if it can't be loaded.
+func aiSettings() llm.Settings {
+	prof, err := profile.LoadProfile(configPath)
+	if err != nil {
+		return nil
+	}
+	return prof.AI
+}
+
+// newProvider returns the provider configured for task, answering prompts it
+// has answered before from the AI cache in the database (see cacheCmd).
+// initApp must have been called.
+func newProvider(settings llm.Settings, task llm.Task) (llm.Provider, error) {
+	provider, err := settings.New(task)
+	if err != nil {
+		return nil, err
+	}
+	if ttl := aiCacheTTL(); ttl > 0 {
+		if chain, ok := provider.(*llm.Chain); ok {
+			chain.SetCache(database.NewAICache(ttl))
+		}
+	}
+	return provider, nil
+}
+
+// aiCacheTTL returns how long cached AI replies are used: AI_CACHE_TTL_DAYS,
+// 30 days by default. 0 turns the cache off.
+func aiCacheTTL() time.Duration {
+	days, err := strconv.Atoi(getEnv("AI_CACHE_TTL_DAYS", "30"))
+	if err != nil || days < 0 {
+		log.Printf("Warning: invalid AI_CACHE_TTL_DAYS, using 30")
+		days = 30
+	}
+	return time.Duration(days) * 24 * time.Hour
+}
+
 // getEnv gets an environment variable or returns a default value
 func getEnv(key, defaultValue string) string {
 	value := os.Getenv(key)
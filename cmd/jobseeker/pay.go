@@ -0,0 +1,66 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/guidebee/jobseeker/internal/database"
+	"github.com/guidebee/jobseeker/internal/profile"
+	"github.com/guidebee/jobseeker/internal/salary"
+	"gorm.io/gorm"
+)
+
+// contractPay matches jobs that are compared against the contract rates in
+// the profile rather than the salary minimum (see Profile.MinimumAnnualPay).
+const contractPay = "(job_type = 'contract' OR salary_period IN ('hour', 'day'))"
+
+// filterByPay restricts a job query to jobs whose annualised pay is at least
+// minSalary and, with meetsMinimum, at least the profile's minimum for the
+// kind of role. Jobs without a parsed salary are left out by either filter.
+func filterByPay(query *gorm.DB, prof *profile.Profile, minSalary int, meetsMinimum bool) *gorm.DB {
+	if minSalary > 0 {
+		query = query.Where("salary_annual >= ?", minSalary)
+	}
+	if meetsMinimum {
+		query = query.Where("salary_annual > 0").
+			Where("("+contractPay+" AND salary_annual >= ?) OR (NOT "+contractPay+" AND salary_annual >= ?)",
+				prof.MinimumAnnualPay("contract", ""), prof.MinimumAnnualPay("permanent", ""))
+	}
+	return query
+}
+
+// freshness orders jobs by when the board listed them, falling back to when
+// they were found for boards that don't say.
+const freshness = "COALESCE(listed_at, created_at) DESC"
+
+// orderJobs sorts a job query: "date" (newest found first), "fresh" (newest
+// listing first), "salary" (highest annualised pay first) or "score" (best
+// match first).
+func orderJobs(query *gorm.DB, sortBy string) (*gorm.DB, error) {
+	switch sortBy {
+	case "", "date":
+		return query.Order("created_at DESC"), nil
+	case "fresh":
+		return query.Order(freshness), nil
+	case "salary":
+		return query.Order("salary_annual DESC").Order("created_at DESC"), nil
+	case "score":
+		return query.Order("match_score DESC").Order("created_at DESC"), nil
+	}
+	return nil, fmt.Errorf("unknown sort order %q (use date, fresh, salary or score)", sortBy)
+}
+
+// annualPayNote describes a job's annualised pay for display after its
+// salary text, e.g. "≈ $231k/yr", or "" when the salary wasn't understood.
+func annualPayNote(job *database.Job) string {
+	if job.SalaryAnnual == 0 {
+		return ""
+	}
+	note := fmt.Sprintf("≈ $%.0fk/yr", job.SalaryAnnual/1000)
+	if job.SalaryCurrency != "" && job.SalaryCurrency != "AUD" {
+		note += " " + job.SalaryCurrency
+	}
+	if job.SalaryPeriod == salary.Hour || job.SalaryPeriod == salary.Day {
+		note += fmt.Sprintf(" at %d days", salary.WorkingDaysPerYear)
+	}
+	return note
+}
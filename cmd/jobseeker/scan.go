@@ -1,19 +1,30 @@
 package main
 
 import (
+	"errors"
 	"fmt"
+	"io"
 	"log"
 	"os"
+	"sort"
 	"strconv"
+	"strings"
+	"sync"
+	"time"
 
 	"github.com/guidebee/jobseeker/internal/database"
+	"github.com/guidebee/jobseeker/internal/dedup"
 	"github.com/guidebee/jobseeker/internal/profile"
 	"github.com/guidebee/jobseeker/internal/resume"
 	"github.com/guidebee/jobseeker/internal/scraper"
-	"github.com/guidebee/jobseeker/pkg/claude"
 	"github.com/spf13/cobra"
 )
 
+var (
+	scanBoardName string
+	scanNoRecheck bool
+)
+
 var scanCmd = &cobra.Command{
 	Use:   "scan",
 	Short: "Scan job boards for new opportunities",
@@ -38,170 +49,439 @@ func runScan(cmd *cobra.Command, args []string) {
 	// Get scraper settings from environment
 	delayMs, _ := strconv.Atoi(getEnv("SCRAPER_DELAY_MS", "2000"))
 
-	// Create scraper
-	s := scraper.NewScraper(delayMs)
+	// Create scraper; pagination stops once a page holds only stored jobs,
+	// and every site is held to the profile's politeness settings
+	s := scraper.NewScraper(delayMs, scraper.WithPoliteness(prof.Politeness))
+	s.SetKnownJobs(func(externalID string) bool {
+		return scraper.JobExists(externalID, user.ID)
+	})
+
+	// An empty page for a search that usually finds jobs means a scraper's
+	// selectors broke; the page is kept so the selectors can be fixed
+	s.SetSnapshotDir(getEnv("SCRAPER_SNAPSHOT_DIR", "./snapshots"))
+	s.SetExpectedYield(func(board, target string) int {
+		median, err := database.MedianYield(user.ID, board, target, yieldHistory)
+		if err != nil {
+			log.Printf("Warning: %v", err)
+		}
+		return median
+	})
 
 	fmt.Println("Starting job scan...")
 
-	// Scan each enabled job board
-	totalJobs := 0
+	// Scan each enabled job board in a stable order
+	names := make([]string, 0, len(prof.JobBoards))
+	for name := range prof.JobBoards {
+		names = append(names, name)
+	}
+	sort.Strings(names)
 
-	// SEEK
-	if prof.JobBoards["seek"].Enabled {
-		fmt.Println("\nScanning SEEK...")
+	if scanBoardName != "all" {
+		if _, ok := prof.JobBoards[scanBoardName]; !ok {
+			log.Fatalf("Job board %q is not configured under job_boards in %s", scanBoardName, configPath)
+		}
+	}
 
-		// Get static URLs from config
-		staticURLs := prof.JobBoards["seek"].SearchURLs
+	// Collect every enabled board's targets; boards are then scanned together
+	var tasks []scanTask
+	var scanned []string
+	var keywords *resume.KeywordExtraction
+	keywordsLoaded := false
+	for _, name := range names {
+		cfg := prof.JobBoards[name]
+		if scanBoardName != "all" {
+			// An explicitly requested board is scanned even if disabled
+			if name != scanBoardName {
+				continue
+			}
+		} else if !boardEnabled(name, cfg) {
+			continue
+		}
 
-		// Try to generate dynamic URLs from resume
-		dynamicURLs := generateDynamicURLs(prof)
+		board, err := scraper.NewBoard(name, s, cfg)
+		if err != nil {
+			log.Printf("Skipping %s: %v", name, err)
+			continue
+		}
 
-		// Merge URLs
+		// Get static search URLs and company slugs from config
+		staticURLs := cfg.Targets()
+
+		// Search boards also get URLs generated from the resumes; their
+		// keywords are loaded once, for the first board that needs them
+		var dynamicURLs []string
+		if resume.HasSearchURLBuilder(name) {
+			if !keywordsLoaded {
+				keywords, keywordsLoaded = loadSearchKeywords(prof.AI, user.ID), true
+			}
+			dynamicURLs = resume.GenerateSearchURLs(name, keywords, searchOptions(prof, cfg))
+		}
 		allURLs := resume.MergeSearchURLs(dynamicURLs, staticURLs)
 
 		if len(allURLs) == 0 {
-			log.Println("No search URLs configured or generated")
-		} else {
-			fmt.Printf("Total search URLs: %d", len(allURLs))
-			if len(staticURLs) > 0 {
-				fmt.Printf(" (%d from config", len(staticURLs))
-			}
+			log.Printf("No %s search URLs or companies configured or generated", name)
+			continue
+		}
+
+		fmt.Printf("%s: %d search targets", board.Name(), len(allURLs))
+		if len(staticURLs) > 0 {
+			fmt.Printf(" (%d from config", len(staticURLs))
 			if len(dynamicURLs) > 0 {
 				fmt.Printf(" + %d from resume", len(dynamicURLs))
 			}
-			fmt.Println(")")
-
-			for i, seekURL := range allURLs {
-				fmt.Printf("\n[%d/%d] Scanning: %s\n", i+1, len(allURLs), seekURL)
-				jobs, err := s.ScrapeSeek(seekURL)
-				if err != nil {
-					log.Printf("Error scraping SEEK: %v", err)
-					continue
-				}
-
-				fmt.Printf("  Found %d jobs\n", len(jobs))
-				err = scraper.SaveJobs(jobs, user.ID)
-				if err != nil {
-					log.Printf("  Error saving jobs: %v", err)
-				}
-				totalJobs += len(jobs)
+			fmt.Print(")")
+		}
+		fmt.Println()
+
+		for _, target := range allURLs {
+			tasks = append(tasks, scanTask{board: board, target: target})
+		}
+		scanned = append(scanned, name)
+	}
+
+	workers, err := strconv.Atoi(getEnv("SCRAPER_MAX_CONCURRENT", "3"))
+	if err != nil || workers < 1 {
+		workers = 1
+	}
+	fmt.Printf("\nScanning %d targets with %d workers...\n", len(tasks), workers)
+
+	// Keep a record of the run; the scan itself doesn't depend on it
+	run, err := database.StartScanRun(user.ID, scanned)
+	if err != nil {
+		log.Printf("Warning: %v", err)
+	}
+
+	results := runScanTasks(tasks, workers, user.ID, run)
+
+	totalJobs, newJobs, changedJobs := 0, 0, 0
+	var broken []string
+	for _, name := range scanned {
+		var boardResults []targetResult
+		boardBroken := false
+		for i, r := range results {
+			if tasks[i].board.Name() == name {
+				boardResults = append(boardResults, r)
+				totalJobs += r.found
+				newJobs += r.saved.New
+				changedJobs += r.saved.Updated
+				boardBroken = boardBroken || errors.Is(r.err, scraper.ErrSelectorsBroken)
 			}
 		}
+		printTargetResults(os.Stdout, name, boardResults)
+		if boardBroken {
+			broken = append(broken, name)
+		}
 	}
 
-	// LinkedIn
-	if prof.JobBoards["linkedin"].Enabled && getEnv("LINKEDIN_SCAN_ENABLED", "true") != "false" {
-		fmt.Println("\nScanning LinkedIn...")
+	if !scanNoRecheck {
+		recheckStaleJobs(s, user.ID, scanned)
+	}
+
+	// Group copies of the same role across boards and agencies
+	if stats, err := dedup.ClusterUserJobs(user.ID); err != nil {
+		log.Printf("Warning: %v", err)
+	} else if stats.Updated > 0 {
+		fmt.Printf("\n✓ %d jobs are copies of another listing (%d roles)\n", stats.Duplicates, stats.Clusters)
+	}
+
+	if run != nil {
+		if err := database.FinishScanRun(run); err != nil {
+			log.Printf("Warning: %v", err)
+		}
+	}
 
-		linkedinURLs := prof.JobBoards["linkedin"].SearchURLs
+	fmt.Printf("\n✓ Scan complete! Found %d total jobs (%d new, %d changed)\n", totalJobs, newJobs, changedJobs)
+	if len(broken) > 0 {
+		fmt.Printf("⚠ Broken: %s returned pages their scrapers no longer understand.\n", strings.Join(broken, ", "))
+		fmt.Println("  These boards need selector fixes; their missing jobs are not a quiet day.")
+	}
+	if paused := s.PausedSites(); len(paused) > 0 {
+		fmt.Printf("⏸ Paused: %s kept blocking requests, so the rest of their searches were skipped.\n", strings.Join(paused, ", "))
+		fmt.Println("  Lower requests_per_minute for them under politeness in the profile, or scan again later.")
+	}
+	if run != nil {
+		fmt.Printf("Run 'jobseeker scans %d' to review this scan\n", run.ID)
+	}
+	fmt.Println("Run 'jobseeker analyze' to evaluate new jobs with AI")
+}
 
-		if len(linkedinURLs) == 0 {
-			log.Println("No LinkedIn search URLs configured")
-		} else {
-			fmt.Printf("Configured %d search URLs\n", len(linkedinURLs))
+const (
+	// staleAfter is how long a stored job can go unseen by scans before its
+	// listing is re-checked for closure.
+	staleAfter = 3 * 24 * time.Hour
+	// maxRechecks caps the closure checks made by one scan.
+	maxRechecks = 30
+)
 
-			for i, linkedinURL := range linkedinURLs {
-				fmt.Printf("\n[%d/%d] Scanning: %s\n", i+1, len(linkedinURLs), linkedinURL)
-				jobs, err := s.ScrapeLinkedIn(linkedinURL)
-				if err != nil {
-					log.Printf("Error scraping LinkedIn: %v", err)
-					continue
-				}
+// recheckStaleJobs visits the pages of jobs the scanned boards haven't
+// returned for a while and marks the closed ones expired. Search results only
+// cover the first pages of each search, so a missing job may simply have
+// dropped down the results; the visit confirms the closure.
+func recheckStaleJobs(s *scraper.Scraper, userID uint, boards []string) {
+	if len(boards) == 0 {
+		return
+	}
+	stale, err := database.StaleJobs(userID, boards, time.Now().Add(-staleAfter), maxRechecks)
+	if err != nil {
+		log.Printf("Warning: %v", err)
+		return
+	}
+	if len(stale) == 0 {
+		return
+	}
 
-				fmt.Printf("  Found %d jobs\n", len(jobs))
-				err = scraper.SaveJobs(jobs, user.ID)
-				if err != nil {
-					log.Printf("  Error saving jobs: %v", err)
-				}
-				totalJobs += len(jobs)
+	fmt.Printf("\nRe-checking %d jobs not seen for %d days...\n", len(stale), int(staleAfter.Hours()/24))
+	closed := 0
+	for i := range stale {
+		job := &stale[i]
+		isClosed, err := s.ListingClosed(job)
+		switch {
+		case err != nil:
+			log.Printf("  Could not re-check %s: %v", job.URL, err)
+		case isClosed:
+			if err := database.MarkExpired(job); err != nil {
+				log.Printf("Warning: %v", err)
+				continue
+			}
+			closed++
+			fmt.Printf("  ✗ closed: %s at %s\n", job.Title, job.Company)
+		default:
+			if err := database.MarkSeen(job); err != nil {
+				log.Printf("Warning: %v", err)
 			}
 		}
 	}
+	fmt.Printf("  %d of %d listings have closed\n", closed, len(stale))
+}
 
-	// Indeed
-	if prof.JobBoards["indeed"].Enabled {
-		fmt.Println("\nScanning Indeed...")
+// yieldHistory is how many past scans of a search its expected yield is
+// taken from.
+const yieldHistory = 5
 
-		indeedURLs := prof.JobBoards["indeed"].SearchURLs
+// scanTask is one board target (a search URL, company slug or site name).
+type scanTask struct {
+	board  scraper.Board
+	target string
+}
 
-		if len(indeedURLs) == 0 {
-			log.Println("No Indeed search URLs configured")
-		} else {
-			fmt.Printf("Configured %d search URLs\n", len(indeedURLs))
+// targetResult is the outcome of searching one board target.
+type targetResult struct {
+	target   string
+	started  time.Time
+	finished time.Time
+	found    int
+	saved    scraper.SaveStats
+	misses   []string // selectors that missed part of a page that still yielded jobs
+	err      error
+}
 
-			for i, indeedURL := range indeedURLs {
-				fmt.Printf("\n[%d/%d] Scanning: %s\n", i+1, len(indeedURLs), indeedURL)
-				jobs, err := s.ScrapeIndeed(indeedURL)
-				if err != nil {
-					log.Printf("Error scraping Indeed: %v", err)
-					continue
-				}
+// saveRequest asks the saver goroutine to store jobs and report what it did.
+type saveRequest struct {
+	jobs  []*database.Job
+	stats chan<- scraper.SaveStats
+}
 
-				fmt.Printf("  Found %d jobs\n", len(jobs))
-				err = scraper.SaveJobs(jobs, user.ID)
-				if err != nil {
-					log.Printf("  Error saving jobs: %v", err)
-				}
-				totalJobs += len(jobs)
+// runScanTasks searches every target on a pool of workers and returns the
+// results in task order. Boards share the pool, so different sites are
+// scanned at the same time while each site's LimitRule keeps requests to it
+// polite. Jobs are saved by a single goroutine, and progress is printed as
+// one line per finished target so concurrent output stays readable. Each
+// result is recorded against run when it is not nil.
+func runScanTasks(tasks []scanTask, workers int, userID uint, run *database.ScanRun) []targetResult {
+	results := make([]targetResult, len(tasks))
+
+	toSave := make(chan saveRequest)
+	saved := make(chan struct{})
+	go func() {
+		defer close(saved)
+		for req := range toSave {
+			stats, err := scraper.SaveJobs(req.jobs, userID)
+			if err != nil {
+				log.Printf("Error saving jobs: %v", err)
 			}
+			req.stats <- stats
 		}
+	}()
+
+	next := make(chan int)
+	finished := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range next {
+				results[i] = scanTarget(tasks[i], userID, toSave)
+				finished <- i
+			}
+		}()
+	}
+	go func() {
+		for i := range tasks {
+			next <- i
+		}
+		close(next)
+		wg.Wait()
+		close(finished)
+	}()
+
+	done := 0
+	for i := range finished {
+		done++
+		r := results[i]
+		if run != nil {
+			recordTargetResult(run, tasks[i].board.Name(), r)
+		}
+		if errors.Is(r.err, scraper.ErrSelectorsBroken) {
+			fmt.Printf("[%d/%d] ⚠ BROKEN %s %s: %v\n", done, len(tasks), tasks[i].board.Name(), r.target, r.err)
+			continue
+		}
+		if skipped(r.err) {
+			fmt.Printf("[%d/%d] ⏸ SKIPPED %s %s: %v\n", done, len(tasks), tasks[i].board.Name(), r.target, r.err)
+			continue
+		}
+		if r.err != nil {
+			fmt.Printf("[%d/%d] ✗ %s %s: %v\n", done, len(tasks), tasks[i].board.Name(), r.target, r.err)
+			continue
+		}
+		fmt.Printf("[%d/%d] ✓ %s %s: %d jobs (%d new, %d changed)", done, len(tasks), tasks[i].board.Name(), r.target, r.found, r.saved.New, r.saved.Updated)
+		if len(r.misses) > 0 {
+			fmt.Printf(", %d selector misses", len(r.misses))
+		}
+		fmt.Println()
 	}
 
-	fmt.Printf("\n✓ Scan complete! Found %d total jobs\n", totalJobs)
-	fmt.Println("Run 'jobseeker analyze' to evaluate new jobs with AI")
+	close(toSave)
+	<-saved
+	return results
 }
 
-// generateDynamicURLs creates search URLs from resume content
-func generateDynamicURLs(prof *profile.Profile) []string {
-	// Try to load resumes
-	resumesDir := "./resumes"
-	resumes, err := resume.LoadResumes(resumesDir)
-	if err != nil || len(resumes) == 0 {
-		// No resumes found, return empty
-		return []string{}
-	}
+// scanTarget searches one target, fetches details for jobs that aren't
+// stored yet and hands the jobs to the saver.
+func scanTarget(task scanTask, userID uint, toSave chan<- saveRequest) (res targetResult) {
+	res = targetResult{target: task.target, started: time.Now()}
+	defer func() { res.finished = time.Now() }()
 
-	fmt.Printf("✓ Found %d resume(s), generating search keywords...\n", len(resumes))
+	jobs, err := task.board.Search(task.target)
+	if err != nil {
+		res.err = err
+		return res
+	}
+	if m, ok := task.board.(scraper.SelectorMisser); ok {
+		res.misses = m.SelectorMisses(task.target)
+	}
 
-	// Get Claude API key
-	apiKey := os.Getenv("CLAUDE_API_KEY")
-	if apiKey == "" {
-		log.Println("Warning: CLAUDE_API_KEY not set, skipping dynamic URL generation")
-		return []string{}
+	for _, job := range jobs {
+		// Stored jobs already have their details; don't fetch them again
+		if scraper.JobExists(job.ExternalID, userID) {
+			continue
+		}
+		if err := task.board.FetchDetail(job); err != nil {
+			log.Printf("  Could not fetch details for %s: %v", job.Title, err)
+		}
 	}
 
-	// Create Claude client
-	claudeClient := claude.NewClient(apiKey)
+	res.found = len(jobs)
+	if len(jobs) > 0 {
+		stats := make(chan scraper.SaveStats)
+		toSave <- saveRequest{jobs: jobs, stats: stats}
+		res.saved = <-stats
+	}
+	return res
+}
 
-	// Use first resume for keyword extraction
-	// TODO: Could merge keywords from all resumes
-	selectedResume := resumes[0]
-	fmt.Printf("  Analyzing: %s\n", selectedResume.Filename)
+// recordTargetResult stores a target's outcome as part of a scan run.
+func recordTargetResult(run *database.ScanRun, board string, r targetResult) {
+	result := &database.ScanResult{
+		ScanRunID:  run.ID,
+		Board:      board,
+		SearchURL:  r.target,
+		StartedAt:  r.started,
+		FinishedAt: r.finished,
+		JobsFound:  r.found,
+		NewJobs:    r.saved.New,
+		Duplicates: r.saved.Duplicates,
+	}
+	if r.err != nil {
+		result.Error = r.err.Error()
+		result.Broken = errors.Is(r.err, scraper.ErrSelectorsBroken)
+	}
+	if err := database.RecordScanResult(result); err != nil {
+		log.Printf("Warning: %v", err)
+	}
+}
 
-	keywords, err := resume.ExtractKeywords(selectedResume, claudeClient)
-	if err != nil {
-		log.Printf("Warning: Failed to extract keywords: %v", err)
-		return []string{}
+// printTargetResults summarises a board's scan per target, so a broken
+// search URL or careers site selector stands out, as do selectors that
+// missed only some of a page.
+func printTargetResults(w io.Writer, boardName string, results []targetResult) {
+	failed, partial := 0, 0
+	fmt.Fprintf(w, "\n%s results by target:\n", boardName)
+	for _, r := range results {
+		if errors.Is(r.err, scraper.ErrSelectorsBroken) {
+			failed++
+			fmt.Fprintf(w, "  ⚠ BROKEN %s: %v\n", r.target, r.err)
+			continue
+		}
+		if skipped(r.err) {
+			failed++
+			fmt.Fprintf(w, "  ⏸ SKIPPED %s: %v\n", r.target, r.err)
+			continue
+		}
+		if r.err != nil {
+			failed++
+			fmt.Fprintf(w, "  ✗ %s: %v\n", r.target, r.err)
+			continue
+		}
+		fmt.Fprintf(w, "  ✓ %s: %d jobs\n", r.target, r.found)
+		if len(r.misses) > 0 {
+			partial++
+		}
+		for _, miss := range r.misses {
+			fmt.Fprintf(w, "      ⚠ %s\n", miss)
+		}
+	}
+	if failed > 0 {
+		fmt.Fprintf(w, "  %d of %d targets failed\n", failed, len(results))
 	}
+	if partial > 0 {
+		fmt.Fprintf(w, "  %d of %d targets had selector misses\n", partial, len(results))
+	}
+}
 
-	fmt.Printf("  Extracted keywords:\n")
-	fmt.Printf("    Primary skills: %v\n", keywords.PrimarySkills)
-	fmt.Printf("    Roles: %v\n", keywords.Roles)
-	fmt.Printf("    Search terms: %d generated\n", len(keywords.SearchKeywords))
+// skipped reports whether a target was left alone rather than failing: its
+// site was paused after repeated blocks, or robots.txt disallows it.
+func skipped(err error) bool {
+	return errors.Is(err, scraper.ErrSitePaused) || errors.Is(err, scraper.ErrDisallowed)
+}
 
-	// Get preferred location from config
-	location := "melbourne" // Default
-	if len(prof.Locations) > 0 {
-		location = prof.Locations[0]
+// boardEnabled reports whether a configured board should be scanned. Any board
+// can also be switched off from the environment, e.g. LINKEDIN_SCAN_ENABLED=false.
+func boardEnabled(name string, cfg profile.JobBoard) bool {
+	if !cfg.Enabled {
+		return false
 	}
+	return getEnv(strings.ToUpper(name)+"_SCAN_ENABLED", "true") != "false"
+}
 
-	// Generate URLs
-	urls := resume.GenerateSearchURLs(keywords, location)
-
-	return urls
+// searchOptions are the locations, arrangements and job types from the
+// profile, with the board's own cap and age limit, that resume search URLs
+// are generated for.
+func searchOptions(prof *profile.Profile, cfg profile.JobBoard) resume.SearchOptions {
+	locations := prof.Locations
+	if len(locations) == 0 {
+		locations = []string{"Melbourne"}
+	}
+	return resume.SearchOptions{
+		Locations:        locations,
+		Arrangements:     prof.Preferences.WorkArrangements,
+		JobTypes:         prof.Preferences.JobTypes,
+		PostedWithinDays: cfg.PostedWithinDays,
+		MaxURLs:          cfg.MaxGeneratedURLs,
+	}
 }
 
 func init() {
-	// Add scan-specific flags if needed
-	// scanCmd.Flags().StringP("board", "b", "all", "Job board to scan (seek, linkedin, all)")
+	scanCmd.Flags().StringVarP(&scanBoardName, "board", "b", "all", "Job board to scan (seek, linkedin, careers, ..., all)")
+	scanCmd.Flags().BoolVar(&scanNoRecheck, "no-recheck", false, "Don't re-check jobs missing from recent scans for closed listings")
 }
@@ -0,0 +1,43 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/guidebee/jobseeker/internal/scraper"
+)
+
+func TestPrintTargetResults(t *testing.T) {
+	// A board with a single careers site still shows how that site did
+	var out strings.Builder
+	printTargetResults(&out, "careers", []targetResult{
+		{target: "Acme", err: fmt.Errorf("acme: %w", scraper.ErrSelectorsBroken)},
+	})
+	if !strings.Contains(out.String(), "careers results by target:") || !strings.Contains(out.String(), "⚠ BROKEN Acme") {
+		t.Errorf("single broken target not shown:\n%s", out.String())
+	}
+
+	// A site whose selectors missed some cards shows the misses under its count
+	out.Reset()
+	printTargetResults(&out, "careers", []targetResult{
+		{target: "Acme", found: 3, misses: []string{`title selector ".title" matched nothing in 1 of 4 cards`}},
+	})
+	for _, want := range []string{"✓ Acme: 3 jobs\n      ⚠ title selector \".title\" matched nothing in 1 of 4 cards", "1 of 1 targets had selector misses"} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("output lacks %q:\n%s", want, out.String())
+		}
+	}
+
+	out.Reset()
+	printTargetResults(&out, "seek", []targetResult{
+		{target: "https://www.seek.com.au/golang-jobs", found: 12},
+		{target: "https://www.seek.com.au/rust-jobs", err: errors.New("timeout")},
+	})
+	for _, want := range []string{"✓ https://www.seek.com.au/golang-jobs: 12 jobs", "✗ https://www.seek.com.au/rust-jobs: timeout", "1 of 2 targets failed"} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("output lacks %q:\n%s", want, out.String())
+		}
+	}
+}
@@ -0,0 +1,130 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/guidebee/jobseeker/internal/database"
+	"github.com/spf13/cobra"
+)
+
+var scansLimit int
+
+var scansCmd = &cobra.Command{
+	Use:   "scans [run-id]",
+	Short: "List recent scan runs",
+	Long: `Lists recent 'jobseeker scan' runs with how many jobs each found and saved.
+Give a run ID to see the result of every search URL in that run.
+
+Searches whose page no longer matched the scraper's selectors are marked
+BROKEN; a copy of each such page is saved under SCRAPER_SNAPSHOT_DIR. Searches
+that succeeded but returned no jobs are flagged too, since a search that used
+to find jobs and suddenly finds none may also mean the board changed its
+markup.
+
+Examples:
+  jobseeker scans
+  jobseeker scans --limit 30
+  jobseeker scans 42`,
+	Args: cobra.MaximumNArgs(1),
+	Run:  runScans,
+}
+
+func runScans(cmd *cobra.Command, args []string) {
+	if _, err := initApp(); err != nil {
+		log.Fatalf("Initialization failed: %v", err)
+	}
+
+	user, err := database.GetCurrentUser()
+	if err != nil {
+		log.Fatalf("Failed to get current user: %v\nRun 'jobseeker init' first", err)
+	}
+
+	if len(args) == 1 {
+		runID, err := strconv.ParseUint(args[0], 10, 64)
+		if err != nil {
+			log.Fatalf("Invalid scan run ID %q", args[0])
+		}
+		run, err := database.GetScanRun(user.ID, uint(runID))
+		if err != nil {
+			log.Fatalf("%v", err)
+		}
+		printScanRunDetail(run)
+		return
+	}
+
+	runs, err := database.GetRecentScanRuns(user.ID, scansLimit)
+	if err != nil {
+		log.Fatalf("%v", err)
+	}
+	if len(runs) == 0 {
+		fmt.Println("No scans recorded yet. Run 'jobseeker scan' first")
+		return
+	}
+
+	fmt.Printf("Recent scans (%d):\n\n", len(runs))
+	for _, run := range runs {
+		fmt.Printf("#%d  %s  %s  [%s]\n", run.ID, run.StartedAt.Format("2006-01-02 15:04"), scanDuration(run), run.Boards)
+		if run.FinishedAt == nil {
+			fmt.Printf("     %d targets recorded before the scan stopped\n", len(run.Results))
+		} else {
+			fmt.Printf("     %d targets | %d jobs found | %d new | %d duplicates | %d errors\n",
+				run.Targets, run.JobsFound, run.NewJobs, run.Duplicates, run.Errors)
+		}
+		if broken := run.BrokenBoards(); len(broken) > 0 {
+			fmt.Printf("     ⚠ BROKEN: %s\n", strings.Join(broken, ", "))
+		}
+		if zero := run.ZeroYield(); len(zero) > 0 {
+			fmt.Printf("     ⚠ %d searches returned no jobs\n", len(zero))
+		}
+		fmt.Println()
+	}
+	fmt.Println("Run 'jobseeker scans <id>' for per-URL results")
+}
+
+// printScanRunDetail prints every search result of one run.
+func printScanRunDetail(run *database.ScanRun) {
+	fmt.Printf("Scan #%d  %s  %s\n", run.ID, run.StartedAt.Format("2006-01-02 15:04:05"), scanDuration(*run))
+	fmt.Printf("Boards: %s\n\n", run.Boards)
+
+	board := ""
+	for _, res := range run.Results {
+		if res.Board != board {
+			board = res.Board
+			fmt.Printf("%s:\n", board)
+		}
+
+		elapsed := res.FinishedAt.Sub(res.StartedAt).Round(time.Second)
+		switch {
+		case res.Broken:
+			fmt.Printf("  ⚠ BROKEN %s (%s)\n      %s\n", res.SearchURL, elapsed, res.Error)
+		case res.Error != "":
+			fmt.Printf("  ✗ %s (%s)\n      %s\n", res.SearchURL, elapsed, res.Error)
+		case res.JobsFound == 0:
+			fmt.Printf("  ⚠ %s (%s): no jobs found\n", res.SearchURL, elapsed)
+		default:
+			fmt.Printf("  ✓ %s (%s): %d jobs, %d new, %d duplicates\n",
+				res.SearchURL, elapsed, res.JobsFound, res.NewJobs, res.Duplicates)
+		}
+	}
+
+	if run.FinishedAt != nil {
+		fmt.Printf("\nTotal: %d targets | %d jobs found | %d new | %d duplicates | %d errors\n",
+			run.Targets, run.JobsFound, run.NewJobs, run.Duplicates, run.Errors)
+	}
+}
+
+// scanDuration describes how long a run took, or that it never finished.
+func scanDuration(run database.ScanRun) string {
+	if run.FinishedAt == nil {
+		return "(unfinished)"
+	}
+	return run.FinishedAt.Sub(run.StartedAt).Round(time.Second).String()
+}
+
+func init() {
+	scansCmd.Flags().IntVarP(&scansLimit, "limit", "l", 10, "Maximum number of scan runs to show")
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/guidebee/jobseeker/internal/cvtailor"
 	"github.com/guidebee/jobseeker/internal/jd"
 	"github.com/guidebee/jobseeker/internal/resume"
+	"github.com/guidebee/jobseeker/pkg/llm"
 	"github.com/spf13/cobra"
 )
 
@@ -52,11 +53,15 @@ func runTailorCV(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	// Get Claude API key
+	// Get Claude API key; CV tailoring needs Claude's docx skill
 	apiKey := os.Getenv("CLAUDE_API_KEY")
 	if apiKey == "" {
 		return fmt.Errorf("CLAUDE_API_KEY environment variable not set")
 	}
+	provider, err := newProvider(prof.AI, llm.TaskJDAnalysis)
+	if err != nil {
+		return err
+	}
 
 	// Load resumes
 	resumesDir := "resumes"
@@ -90,7 +95,7 @@ func runTailorCV(cmd *cobra.Command, args []string) error {
 	}
 
 	// Create analyzer and tailor
-	analyzer := jd.NewJDAnalyzer(apiKey, prof, resumes)
+	analyzer := jd.NewJDAnalyzer(provider, prof, resumes)
 	tailor := cvtailor.NewCVTailor(apiKey, prof, resumes)
 
 	// Process each job description
@@ -101,7 +106,7 @@ func runTailorCV(cmd *cobra.Command, args []string) error {
 		fmt.Printf("%s\n\n", strings.Repeat("=", 80))
 
 		// Analyze the job description first
-		fmt.Println("Analyzing job description with Claude AI...")
+		fmt.Println("Analyzing job description with AI...")
 		result, err := analyzer.AnalyzeJobDescription(jobDesc)
 		if err != nil {
 			log.Printf("Error analyzing %s: %v", jobDesc.Filename, err)
@@ -1,6 +1,7 @@
 package analyzer
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"log"
@@ -9,21 +10,22 @@ import (
 	"github.com/guidebee/jobseeker/internal/database"
 	"github.com/guidebee/jobseeker/internal/profile"
 	"github.com/guidebee/jobseeker/internal/resume"
-	"github.com/guidebee/jobseeker/pkg/minimax"
+	"github.com/guidebee/jobseeker/pkg/llm"
 )
 
-// Analyzer handles job matching using MiniMax AI
+// Analyzer handles job matching using the provider configured for bulk
+// analysis (MiniMax by default)
 type Analyzer struct {
-	minimaxClient *minimax.Client
+	provider      llm.Provider
 	profile       *profile.Profile
 	resumes       []*resume.Resume
 	useResumes    bool
 }
 
 // NewAnalyzer creates a new job analyzer
-func NewAnalyzer(apiKey string, prof *profile.Profile) *Analyzer {
+func NewAnalyzer(provider llm.Provider, prof *profile.Profile) *Analyzer {
 	return &Analyzer{
-		minimaxClient: minimax.NewClient(apiKey),
+		provider:      provider,
 		profile:       prof,
 		resumes:       nil,
 		useResumes:    false,
@@ -78,36 +80,49 @@ func (a *Analyzer) GetResumeUsed(job *database.Job) string {
 	return selectedResume.Filename
 }
 
-// AnalysisResult represents Claude's analysis of a job
+// AnalysisResult represents the AI provider's analysis of a job
 type AnalysisResult struct {
 	MatchScore int    `json:"match_score"` // 0-100
 	Reasoning  string `json:"reasoning"`
 	Pros       []string `json:"pros"`
 	Cons       []string `json:"cons"`
+
+	// Model is the provider and model that gave the analysis
+	Model string `json:"-"`
+	// Cached is set when the analysis came from the AI cache
+	Cached bool `json:"-"`
 }
 
-// AnalyzeJob sends job details to Claude for analysis
-// Returns a match score (0-100) and detailed reasoning
-func (a *Analyzer) AnalyzeJob(job *database.Job) (*AnalysisResult, error) {
-	// Build the prompt for Claude
+// PromptTemplate versions the job analysis prompts and the parsing of their
+// replies; cached replies to an older version are not reused
+const PromptTemplate = "job-analysis/v1"
+
+// AnalyzeJob sends job details to the AI provider for analysis
+// Returns a match score (0-100) and detailed reasoning. Cancelling ctx stops
+// waiting for the provider's rate limit or a retry; it is safe to call from
+// several goroutines at once.
+func (a *Analyzer) AnalyzeJob(ctx context.Context, job *database.Job) (*AnalysisResult, error) {
+	// Build the prompt for the AI provider
 	prompt := a.buildAnalysisPrompt(job)
 
-	// Send to MiniMax
-	response, err := a.minimaxClient.SendMessage(prompt)
+	// Send to the analysis provider, unless it has answered this prompt before
+	reply, err := llm.Send(ctx, a.provider, llm.Request{Template: PromptTemplate, Prompt: prompt})
 	if err != nil {
-		return nil, fmt.Errorf("failed to get Claude response: %w", err)
+		return nil, fmt.Errorf("failed to get AI response: %w", err)
 	}
 
-	// Parse Claude's response
-	result, err := a.parseAnalysisResponse(response)
+	// Parse the AI provider's response
+	result, err := a.parseAnalysisResponse(reply.Text)
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse analysis: %w", err)
 	}
+	result.Model = reply.Model
+	result.Cached = reply.Cached
 
 	return result, nil
 }
 
-// buildAnalysisPrompt creates a detailed prompt for Claude
+// buildAnalysisPrompt creates a detailed prompt for the AI provider
 func (a *Analyzer) buildAnalysisPrompt(job *database.Job) string {
 	// Build salary/rate preference text based on job type
 	salaryPref := ""
@@ -131,6 +146,7 @@ func (a *Analyzer) buildAnalysisPrompt(job *database.Job) string {
 func (a *Analyzer) buildResumeBasedPrompt(job *database.Job, salaryPref string) string {
 	// Select best resume for this job
 	selectedResume := resume.SelectBestResume(a.resumes, job.Title, job.JobType)
+	rightsLine, rightsRule := a.workRightsPrompt()
 
 	return fmt.Sprintf(`You are a career advisor helping evaluate job opportunities.
 
@@ -140,7 +156,8 @@ MY RESUME:
 MY PREFERENCES:
 - %s
 - Job types interested in: %s
-- Location preferences: %s
+- Work arrangements interested in: %s
+- Location preferences: %s%s
 
 JOB POSTING:
 - Title: %s
@@ -148,6 +165,8 @@ JOB POSTING:
 - Location: %s
 - Salary/Rate: %s
 - Job Type: %s (detected)
+- Work arrangement, seniority, hours: %s (detected)
+- Work rights required: %s (detected)
 - Description: %s
 - Requirements: %s
 
@@ -157,7 +176,7 @@ Analyze this job opportunity based on my resume and provide:
 2. Key reasons for the score (pros and cons)
 3. Your recommendation
 4. Evaluate if compensation meets expectations
-5. Identify which resume experiences are most relevant
+5. Identify which resume experiences are most relevant%s
 
 Respond ONLY with valid JSON in this exact format:
 {
@@ -169,19 +188,26 @@ Respond ONLY with valid JSON in this exact format:
 		truncate(selectedResume.Content, 2000), // Limit resume length
 		salaryPref,
 		strings.Join(a.profile.Preferences.JobTypes, ", "),
+		strings.Join(a.profile.Preferences.WorkArrangements, ", "),
 		strings.Join(a.profile.Locations, ", "),
+		rightsLine,
 		job.Title,
 		job.Company,
 		job.Location,
 		job.Salary,
 		job.JobType,
+		jobClassification(job),
+		jobWorkRights(job),
 		truncate(job.Description, 1000),
 		truncate(job.Requirements, 500),
+		rightsRule,
 	)
 }
 
 // buildConfigBasedPrompt creates a prompt using config.yaml (fallback)
 func (a *Analyzer) buildConfigBasedPrompt(job *database.Job, salaryPref string) string {
+	rightsLine, rightsRule := a.workRightsPrompt()
+
 	return fmt.Sprintf(`You are a career advisor helping evaluate job opportunities.
 
 MY PROFILE:
@@ -190,6 +216,7 @@ MY PROFILE:
 - Location: %s
 - %s
 - Job types interested in: %s
+- Work arrangements interested in: %s%s
 - Summary: %s
 
 JOB POSTING:
@@ -198,6 +225,8 @@ JOB POSTING:
 - Location: %s
 - Salary/Rate: %s
 - Job Type: %s (detected)
+- Work arrangement, seniority, hours: %s (detected)
+- Work rights required: %s (detected)
 - Description: %s
 - Requirements: %s
 
@@ -207,7 +236,7 @@ Analyze this job opportunity and provide:
 2. Key reasons for the score (pros and cons)
 3. Your recommendation
 4. For contract roles, evaluate if the rate meets minimum expectations
-5. For permanent roles, evaluate if the salary meets minimum expectations
+5. For permanent roles, evaluate if the salary meets minimum expectations%s
 
 Respond ONLY with valid JSON in this exact format:
 {
@@ -224,14 +253,19 @@ Respond ONLY with valid JSON in this exact format:
 		a.profile.Profile.Location,
 		salaryPref,
 		strings.Join(a.profile.Preferences.JobTypes, ", "),
+		strings.Join(a.profile.Preferences.WorkArrangements, ", "),
+		rightsLine,
 		a.profile.Summary,
 		job.Title,
 		job.Company,
 		job.Location,
 		job.Salary,
 		job.JobType,
+		jobClassification(job),
+		jobWorkRights(job),
 		truncate(job.Description, 1000),
 		truncate(job.Requirements, 500),
+		rightsRule,
 	)
 }
 
@@ -269,3 +303,55 @@ func truncate(s string, maxLen int) string {
 	return s[:maxLen] + "..."
 }
 
+// workRightsPrompt returns the line of the prompt stating the candidate's
+// citizenship and clearance, and the scoring rule for roles those don't
+// meet. Both are empty when the profile has no eligibility, so that roles
+// needing work rights aren't marked down for a profile that doesn't say.
+func (a *Analyzer) workRightsPrompt() (line, rule string) {
+	e := a.profile.Eligibility
+	if !e.Known() {
+		return "", ""
+	}
+	var rights []string
+	if e.AustralianCitizen != nil {
+		if *e.AustralianCitizen {
+			rights = append(rights, "Australian citizen")
+		} else {
+			rights = append(rights, "not an Australian citizen")
+		}
+	}
+	if c := strings.TrimSpace(e.Clearance); c != "" && !strings.EqualFold(c, "none") {
+		rights = append(rights, "holds "+strings.ToUpper(c)+" clearance")
+	} else {
+		rights = append(rights, "no security clearance")
+	}
+	return "\n- My work rights: " + strings.Join(rights, ", "),
+		"\n6. If the role needs citizenship or a security clearance that my work rights don't meet, it is not viable: score it 10 or lower"
+}
+
+// jobClassification describes the work arrangement, seniority and schedule
+// the job was classified with.
+func jobClassification(job *database.Job) string {
+	var parts []string
+	for _, p := range []string{job.WorkArrangement, job.Seniority, job.Schedule} {
+		if p != "" {
+			parts = append(parts, p)
+		}
+	}
+	if len(parts) == 0 {
+		return "not stated"
+	}
+	return strings.Join(parts, ", ")
+}
+
+// jobWorkRights describes the citizenship and clearance a job requires.
+func jobWorkRights(job *database.Job) string {
+	switch {
+	case job.Clearance != "":
+		return "Australian citizen with " + strings.ToUpper(job.Clearance) + " security clearance"
+	case job.CitizenshipRequired:
+		return "Australian citizens only"
+	}
+	return "none stated"
+}
+
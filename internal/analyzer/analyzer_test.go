@@ -0,0 +1,36 @@
+package analyzer
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/guidebee/jobseeker/internal/database"
+	"github.com/guidebee/jobseeker/internal/profile"
+)
+
+func TestPromptWorkRights(t *testing.T) {
+	job := &database.Job{Title: "Platform Engineer", Description: "Must hold a current NV1 security clearance.", Clearance: "nv1", CitizenshipRequired: true}
+
+	// A profile without an eligibility block says nothing about work rights,
+	// so roles needing them are scored like any other
+	prompt := NewAnalyzer(nil, &profile.Profile{}).buildAnalysisPrompt(job)
+	if strings.Contains(prompt, "My work rights") || strings.Contains(prompt, "not viable") {
+		t.Errorf("prompt for an empty profile states work rights:\n%s", prompt)
+	}
+	if !strings.Contains(prompt, "Work rights required: Australian citizen with NV1 security clearance") {
+		t.Errorf("prompt lost the job's requirements:\n%s", prompt)
+	}
+
+	citizen := false
+	prof := &profile.Profile{Eligibility: profile.Eligibility{AustralianCitizen: &citizen}}
+	prompt = NewAnalyzer(nil, prof).buildAnalysisPrompt(job)
+	if !strings.Contains(prompt, "- My work rights: not an Australian citizen, no security clearance\n") ||
+		!strings.Contains(prompt, "\n6. If the role needs citizenship or a security clearance") {
+		t.Errorf("prompt for a non-citizen lacks their work rights or the viability rule:\n%s", prompt)
+	}
+
+	prof = &profile.Profile{Eligibility: profile.Eligibility{Clearance: "nv2"}}
+	if prompt = NewAnalyzer(nil, prof).buildAnalysisPrompt(job); !strings.Contains(prompt, "- My work rights: holds NV2 clearance\n") {
+		t.Errorf("prompt for an NV2 holder:\n%s", prompt)
+	}
+}
@@ -0,0 +1,229 @@
+// Package classify reads the work arrangement, seniority, schedule and
+// work-rights requirements of a job ad from its text.
+package classify
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Work arrangements.
+const (
+	ArrangementRemote = "remote"
+	ArrangementHybrid = "hybrid"
+	ArrangementOnsite = "onsite"
+)
+
+// Seniority levels.
+const (
+	SeniorityGraduate  = "graduate"
+	SeniorityJunior    = "junior"
+	SeniorityMid       = "mid"
+	SenioritySenior    = "senior"
+	SeniorityLead      = "lead"
+	SeniorityPrincipal = "principal"
+	SeniorityExecutive = "executive"
+)
+
+// Schedules.
+const (
+	ScheduleFullTime = "full-time"
+	SchedulePartTime = "part-time"
+	ScheduleCasual   = "casual"
+)
+
+// Australian security clearances, lowest first.
+const (
+	ClearanceBaseline = "baseline"
+	ClearanceNV1      = "nv1"
+	ClearanceNV2      = "nv2"
+	ClearancePV       = "pv"
+)
+
+// clearanceLevels orders clearances; "" (none required) ranks 0.
+var clearanceLevels = []string{"", ClearanceBaseline, ClearanceNV1, ClearanceNV2, ClearancePV}
+
+// ClearanceRank returns a clearance's position in Baseline < NV1 < NV2 < PV,
+// 0 for none, or -1 if the name is not a clearance.
+func ClearanceRank(clearance string) int {
+	clearance = strings.ToLower(strings.TrimSpace(clearance))
+	if clearance == "none" {
+		return 0
+	}
+	for i, level := range clearanceLevels {
+		if level == clearance {
+			return i
+		}
+	}
+	return -1
+}
+
+// ClearancesUpTo returns the clearances a job may require for someone who
+// holds the given one, including "" for none.
+func ClearancesUpTo(held string) []string {
+	return clearanceLevels[:max(ClearanceRank(held), 0)+1]
+}
+
+// Version is stored with each classified job; bump it when the rules below
+// change so that stored jobs are classified again.
+const Version = 3
+
+// pattern pairs a classification value with the text that indicates it.
+type pattern struct {
+	value string
+	re    *regexp.Regexp
+}
+
+// Arrangement patterns for short fields (location, title, work type) can be
+// loose. Descriptions mention "remote monitoring" or "hybrid cloud", so only
+// phrases about where the work is done count there.
+var (
+	arrangementFieldPatterns = []pattern{
+		{ArrangementHybrid, regexp.MustCompile(`(?i)\bhybrid\b`)},
+		{ArrangementRemote, regexp.MustCompile(`(?i)\b(remote|work from home|wfh)\b`)},
+		{ArrangementOnsite, regexp.MustCompile(`(?i)\b(on-?site|in[- ]office)\b`)},
+	}
+	arrangementDescriptionPatterns = []pattern{
+		{ArrangementHybrid, regexp.MustCompile(`(?i)\bhybrid (work|working|role|arrangement|model|position|environment)\b|\b\d days? (per week |a week )?in (the )?office\b`)},
+		{ArrangementRemote, regexp.MustCompile(`(?i)\b(fully|100%|completely) remote\b|\bremote[- ](first|role|position|working|work)\b|\bwork from (home|anywhere)\b`)},
+		{ArrangementOnsite, regexp.MustCompile(`(?i)\b(fully|100%) (on-?site|office[- ]based)\b|\b(on-?site|office[- ]based) (role|position)\b|\bno remote\b`)},
+	}
+)
+
+// Seniority patterns are checked against the title, highest level first, so
+// "Senior Engineering Manager" is not taken for a mid-level role.
+var seniorityPatterns = []pattern{
+	{SeniorityExecutive, regexp.MustCompile(`(?i)\b(head of|director|chief|cto|cio|vp|vice president)\b`)},
+	{SeniorityPrincipal, regexp.MustCompile(`(?i)\b(principal|staff|distinguished)\b`)},
+	{SeniorityLead, regexp.MustCompile(`(?i)\b(lead|team leader|tech lead)\b`)},
+	{SenioritySenior, regexp.MustCompile(`(?i)\b(senior|sr|snr)\b`)},
+	{SeniorityMid, regexp.MustCompile(`(?i)\b(mid[- ]level|intermediate|mid)\b`)},
+	{SeniorityJunior, regexp.MustCompile(`(?i)\b(junior|jr|jnr|entry[- ]level|associate)\b`)},
+	{SeniorityGraduate, regexp.MustCompile(`(?i)\b(graduate|grad|intern|internship|cadet)\b`)},
+}
+
+// yearsRe finds experience asked for in a description, e.g. "5+ years".
+var yearsRe = regexp.MustCompile(`(?i)\b(\d{1,2})\+?\s*(?:-\s*\d{1,2}\s*)?(?:years|yrs)(?:'|’)?\s*(?:of\s+)?(?:\w+\s+){0,3}?experience`)
+
+var schedulePatterns = []pattern{
+	{ScheduleCasual, regexp.MustCompile(`(?i)\bcasual\b`)},
+	{SchedulePartTime, regexp.MustCompile(`(?i)\bpart[- ]time\b|\b0\.\d\s*fte\b`)},
+	{ScheduleFullTime, regexp.MustCompile(`(?i)\bfull[- ]time\b`)},
+}
+
+// Clearance patterns, highest first. A clearance mentioned without a level
+// is treated as Baseline, the lowest that still needs citizenship.
+var clearancePatterns = []pattern{
+	{ClearancePV, regexp.MustCompile(`(?i)\bpositive vetting\b|\bpv[- ]cleared\b|\bpv\s+(security\s+)?(clearance|vetting)\b`)},
+	{ClearanceNV2, regexp.MustCompile(`(?i)\bnv\s?2\b|\bnegative vetting (level )?2\b`)},
+	{ClearanceNV1, regexp.MustCompile(`(?i)\bnv\s?1\b|\bnegative vetting (level )?1\b`)},
+	{ClearanceBaseline, regexp.MustCompile(`(?i)\bbaseline\s+(security\s+)?(clearance|vetting|cleared)\b|\b(security|agsva|defence|government)\s+clearance\b`)},
+}
+
+// negationBefore and negationAfter find a clearance mentioned only to say it
+// isn't needed: "no security clearance required", "does not require a
+// security clearance", "baseline clearance not required".
+var (
+	negationBefore = regexp.MustCompile(`(?i)\b(no|not|without)(\s+[\w'’-]+){0,3}\s*$`)
+	negationAfter  = regexp.MustCompile(`(?i)^\s*((security\s+)?(clearance|vetting)\s+)?(is\s+)?(not\s+(required|needed|necessary)|unnecessary)\b`)
+)
+
+var citizenshipRe = regexp.MustCompile(`(?i)\baustralian citizens?(hip)?\s+(only|is\s+(required|mandatory|essential)|required)\b|\bmust\s+(be|hold)\s+(an?\s+)?australian citizen|\bcitizenship\s+(is\s+)?(required|mandatory|essential)\b|\bonly\s+australian citizens\b`)
+
+// Ad is the text of a job ad that Classify reads.
+type Ad struct {
+	Title        string
+	Location     string
+	WorkType     string
+	Description  string
+	Requirements string
+}
+
+// Result is what an ad says about the role; fields the ad doesn't indicate
+// are empty.
+type Result struct {
+	WorkArrangement     string
+	Seniority           string
+	Schedule            string
+	Clearance           string
+	CitizenshipRequired bool
+}
+
+// Classify reads the work arrangement, seniority, schedule, clearance and
+// citizenship requirement of an ad from its title, location, work type and
+// description.
+func Classify(ad Ad) Result {
+	var r Result
+	for _, field := range []string{ad.Location, ad.Title, ad.WorkType} {
+		if r.WorkArrangement = match(arrangementFieldPatterns, field); r.WorkArrangement != "" {
+			break
+		}
+	}
+	if r.WorkArrangement == "" {
+		r.WorkArrangement = match(arrangementDescriptionPatterns, ad.Description)
+	}
+
+	r.Seniority = match(seniorityPatterns, ad.Title)
+	if r.Seniority == "" {
+		r.Seniority = seniorityFromYears(ad.Description + " " + ad.Requirements)
+	}
+
+	r.Schedule = match(schedulePatterns, ad.WorkType+" "+ad.Title)
+	if r.Schedule == "" {
+		r.Schedule = match(schedulePatterns, ad.Description)
+	}
+
+	text := ad.Title + " " + ad.Description + " " + ad.Requirements
+	r.Clearance = matchClearance(text)
+	// Every Australian clearance requires citizenship
+	r.CitizenshipRequired = r.Clearance != "" || citizenshipRe.MatchString(text)
+	return r
+}
+
+// match returns the value of the first pattern found in text, or "".
+func match(patterns []pattern, text string) string {
+	if text == "" {
+		return ""
+	}
+	for _, p := range patterns {
+		if p.re.MatchString(text) {
+			return p.value
+		}
+	}
+	return ""
+}
+
+// matchClearance returns the highest clearance text asks for, ignoring
+// mentions that say one isn't needed.
+func matchClearance(text string) string {
+	for _, p := range clearancePatterns {
+		for _, loc := range p.re.FindAllStringIndex(text, -1) {
+			before := text[max(loc[0]-60, 0):loc[0]]
+			if !negationBefore.MatchString(before) && !negationAfter.MatchString(text[loc[1]:]) {
+				return p.value
+			}
+		}
+	}
+	return ""
+}
+
+// seniorityFromYears guesses a level from the most experience the text asks
+// for, for titles like "Software Engineer" that don't say.
+func seniorityFromYears(text string) string {
+	most := -1
+	for _, m := range yearsRe.FindAllStringSubmatch(text, -1) {
+		if n, err := strconv.Atoi(m[1]); err == nil && n > most {
+			most = n
+		}
+	}
+	switch {
+	case most < 0:
+		return ""
+	case most >= 5:
+		return SenioritySenior
+	case most >= 2:
+		return SeniorityMid
+	}
+	return SeniorityJunior
+}
@@ -0,0 +1,64 @@
+package classify
+
+import "testing"
+
+func TestClassify(t *testing.T) {
+	tests := []struct {
+		name string
+		ad   Ad
+		want Result
+	}{
+		{
+			name: "hybrid from location, seniority from title",
+			ad:   Ad{Title: "Senior Go Engineer", Location: "Melbourne VIC (Hybrid)", WorkType: "Full time"},
+			want: Result{WorkArrangement: ArrangementHybrid, Seniority: SenioritySenior, Schedule: ScheduleFullTime},
+		},
+		{
+			name: "remote and part-time from description",
+			ad: Ad{Title: "Software Engineer", Location: "Australia",
+				Description: "This is a fully remote, part-time role (0.6 FTE). You will need 3+ years of Go experience."},
+			want: Result{WorkArrangement: ArrangementRemote, Seniority: SeniorityMid, Schedule: SchedulePartTime},
+		},
+		{
+			name: "NV1 clearance implies citizenship",
+			ad: Ad{Title: "Lead DevOps Engineer", Location: "Canberra ACT", WorkType: "Contract/Temp",
+				Description: "Must hold a current NV1 security clearance. Onsite role in Russell."},
+			want: Result{WorkArrangement: ArrangementOnsite, Seniority: SeniorityLead, Clearance: ClearanceNV1, CitizenshipRequired: true},
+		},
+		{
+			name: "baseline and citizens only",
+			ad: Ad{Title: "Graduate Developer",
+				Description: "Australian citizens only. Ability to obtain a Baseline security clearance. We run a hybrid cloud."},
+			want: Result{Seniority: SeniorityGraduate, Clearance: ClearanceBaseline, CitizenshipRequired: true},
+		},
+		{
+			name: "citizenship without clearance",
+			ad:   Ad{Title: "Head of Engineering", Description: "Australian citizenship is required for this role."},
+			want: Result{Seniority: SeniorityExecutive, CitizenshipRequired: true},
+		},
+		{
+			name: "clearance not required",
+			ad: Ad{Title: "Software Engineer",
+				Description: "No security clearance required. This role does not require a security clearance either, and NV1 clearance is not required."},
+			want: Result{},
+		},
+		{
+			name: "a negated clearance doesn't hide a required one",
+			ad: Ad{Title: "Data Engineer",
+				Description: "No relocation, baseline clearance required. Applicants without a security clearance must obtain one."},
+			want: Result{Clearance: ClearanceBaseline, CitizenshipRequired: true},
+		},
+		{
+			name: "solar PV is not a clearance",
+			ad:   Ad{Title: "Embedded Engineer", Description: "Design firmware for PV inverters and remote monitoring."},
+			want: Result{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Classify(tt.ad); got != tt.want {
+				t.Errorf("Classify = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,134 @@
+package database
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/guidebee/jobseeker/pkg/llm"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// AICache is an llm.Cache kept in the AICacheEntry table. Replies are used
+// for TTL after they were stored, then left for PurgeAICache.
+type AICache struct {
+	TTL time.Duration
+	now func() time.Time
+}
+
+// NewAICache returns a cache whose replies last for ttl.
+func NewAICache(ttl time.Duration) *AICache {
+	return &AICache{TTL: ttl, now: time.Now}
+}
+
+// Get returns the unexpired reply stored under key, counting the hit.
+func (c *AICache) Get(key string) (llm.Reply, bool, error) {
+	db := GetDB()
+	var entry AICacheEntry
+	err := db.Where("key = ? AND expires_at > ?", key, c.now()).Limit(1).Find(&entry).Error
+	if err != nil {
+		return llm.Reply{}, false, fmt.Errorf("failed to look up AI cache: %w", err)
+	}
+	if entry.ID == 0 {
+		return llm.Reply{}, false, nil
+	}
+	if err := db.Model(&entry).UpdateColumn("hits", gorm.Expr("hits + 1")).Error; err != nil {
+		return llm.Reply{}, false, fmt.Errorf("failed to count AI cache hit: %w", err)
+	}
+	return llm.Reply{
+		Text:         entry.Response,
+		Model:        entry.Model,
+		InputTokens:  entry.InputTokens,
+		OutputTokens: entry.OutputTokens,
+	}, true, nil
+}
+
+// Put stores reply under key, replacing an expired entry with the same key.
+// An unexpired entry is kept with its hits, so that two workers storing the
+// same reply at once don't reset the count.
+func (c *AICache) Put(key, template string, reply llm.Reply) error {
+	now := c.now()
+	entry := AICacheEntry{
+		CreatedAt:    now,
+		Key:          key,
+		Model:        reply.Model,
+		Template:     template,
+		Response:     reply.Text,
+		InputTokens:  reply.InputTokens,
+		OutputTokens: reply.OutputTokens,
+		ExpiresAt:    now.Add(c.TTL),
+	}
+	err := GetDB().Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "key"}},
+		DoUpdates: clause.AssignmentColumns([]string{"created_at", "model", "template", "response", "input_tokens", "output_tokens", "hits", "expires_at"}),
+		Where: clause.Where{Exprs: []clause.Expression{
+			clause.Lte{Column: clause.Column{Table: clause.CurrentTable, Name: "expires_at"}, Value: now},
+		}},
+	}).Create(&entry).Error
+	if err != nil {
+		return fmt.Errorf("failed to store AI reply: %w", err)
+	}
+	return nil
+}
+
+// AICachePurge selects the entries PurgeAICache deletes; the zero value
+// selects them all.
+type AICachePurge struct {
+	ExpiredOnly bool
+	// A "provider/model", or a provider for all of its models
+	Model string
+	// A template version, e.g. "job-analysis/v1", or a template for all of
+	// its versions
+	Template string
+}
+
+// PurgeAICache deletes the cache entries p selects and returns how many.
+func PurgeAICache(p AICachePurge) (int64, error) {
+	query := GetDB().Where("1 = 1")
+	if p.ExpiredOnly {
+		query = query.Where("expires_at <= ?", time.Now())
+	}
+	if p.Model != "" {
+		query = query.Where("model = ? OR model LIKE ?", p.Model, p.Model+"/%")
+	}
+	if p.Template != "" {
+		query = query.Where("template = ? OR template LIKE ?", p.Template, p.Template+"/%")
+	}
+	result := query.Delete(&AICacheEntry{})
+	if result.Error != nil {
+		return 0, fmt.Errorf("failed to purge AI cache: %w", result.Error)
+	}
+	return result.RowsAffected, nil
+}
+
+// AICacheStat totals the cache entries of one model and template.
+type AICacheStat struct {
+	Model        string
+	Template     string
+	Entries      int
+	Expired      int
+	Hits         int
+	InputTokens  int // tokens of the prompts when first sent
+	OutputTokens int
+	TokensSaved  int // tokens the hits would have cost
+}
+
+// GetAICacheStats returns the cache totals by model and template.
+func GetAICacheStats() ([]AICacheStat, error) {
+	var stats []AICacheStat
+	err := GetDB().Model(&AICacheEntry{}).
+		Select(`model, template,
+			COUNT(*) AS entries,
+			COALESCE(SUM(CASE WHEN expires_at <= ? THEN 1 ELSE 0 END), 0) AS expired,
+			COALESCE(SUM(hits), 0) AS hits,
+			COALESCE(SUM(input_tokens), 0) AS input_tokens,
+			COALESCE(SUM(output_tokens), 0) AS output_tokens,
+			COALESCE(SUM(hits * (input_tokens + output_tokens)), 0) AS tokens_saved`, time.Now()).
+		Group("model, template").
+		Order("model, template").
+		Scan(&stats).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to total AI cache: %w", err)
+	}
+	return stats, nil
+}
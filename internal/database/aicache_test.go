@@ -0,0 +1,85 @@
+package database
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/guidebee/jobseeker/pkg/llm"
+)
+
+func TestAICache(t *testing.T) {
+	if err := InitDB(filepath.Join(t.TempDir(), "aicache.db")); err != nil {
+		t.Fatalf("InitDB failed: %v", err)
+	}
+
+	now := time.Now()
+	cache := NewAICache(24 * time.Hour)
+	cache.now = func() time.Time { return now }
+
+	key := llm.CacheKey("minimax/MiniMax-M2.5", "job-analysis/v1", "rate this job")
+	reply := llm.Reply{Text: `{"match_score": 80}`, Model: "minimax/MiniMax-M2.5", InputTokens: 1200, OutputTokens: 150}
+	if err := cache.Put(key, "job-analysis/v1", reply); err != nil {
+		t.Fatalf("Put failed: %v", err)
+	}
+	for i := 0; i < 2; i++ {
+		got, ok, err := cache.Get(key)
+		if err != nil || !ok || got != reply {
+			t.Fatalf("Get = %+v, %v, %v; want %+v", got, ok, err, reply)
+		}
+	}
+	if _, ok, _ := cache.Get(llm.CacheKey("minimax/MiniMax-M2.5", "job-analysis/v2", "rate this job")); ok {
+		t.Error("Get found a reply to another template version")
+	}
+
+	// Storing a reply again while it is fresh keeps the first and its hits
+	if err := cache.Put(key, "job-analysis/v1", llm.Reply{Text: "late", Model: reply.Model}); err != nil {
+		t.Fatalf("Put over a fresh entry failed: %v", err)
+	}
+	var entry AICacheEntry
+	GetDB().Where("key = ?", key).First(&entry)
+	if entry.Response != reply.Text || entry.Hits != 2 {
+		t.Errorf("entry = %q with %d hits, want the first reply with 2", entry.Response, entry.Hits)
+	}
+
+	// Expired replies are not used, and are replaced when stored again
+	now = now.Add(25 * time.Hour)
+	if _, ok, _ := cache.Get(key); ok {
+		t.Error("Get returned an expired reply")
+	}
+	reply.Text = `{"match_score": 82}`
+	if err := cache.Put(key, "job-analysis/v1", reply); err != nil {
+		t.Fatalf("Put over an expired entry failed: %v", err)
+	}
+	if got, ok, _ := cache.Get(key); !ok || got.Text != reply.Text {
+		t.Errorf("Get = %+v, %v; want the new reply", got, ok)
+	}
+
+	other := NewAICache(-time.Hour) // stored already expired
+	for _, k := range []string{"a", "b"} {
+		if err := other.Put(k, "resume-keywords/v1", llm.Reply{Text: "{}", Model: "claude/claude-sonnet-4-5"}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	stats, err := GetAICacheStats()
+	if err != nil || len(stats) != 2 {
+		t.Fatalf("GetAICacheStats = %+v, %v", stats, err)
+	}
+	if s := stats[1]; s.Model != "minimax/MiniMax-M2.5" || s.Entries != 1 || s.Hits != 1 || s.TokensSaved != 1350 {
+		t.Errorf("minimax stats = %+v", s)
+	}
+	if s := stats[0]; s.Template != "resume-keywords/v1" || s.Entries != 2 || s.Expired != 2 {
+		t.Errorf("claude stats = %+v", s)
+	}
+
+	if n, err := PurgeAICache(AICachePurge{ExpiredOnly: true}); err != nil || n != 2 {
+		t.Errorf("purging expired entries deleted %d, %v; want 2", n, err)
+	}
+	if n, _ := PurgeAICache(AICachePurge{Model: "claude"}); n != 0 {
+		t.Errorf("purging claude deleted %d entries, want 0", n)
+	}
+	if n, err := PurgeAICache(AICachePurge{Model: "minimax", Template: "job-analysis"}); err != nil || n != 1 {
+		t.Errorf("purging minimax job analyses deleted %d, %v; want 1", n, err)
+	}
+}
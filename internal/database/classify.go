@@ -0,0 +1,67 @@
+package database
+
+import (
+	"fmt"
+
+	"github.com/guidebee/jobseeker/internal/classify"
+	"github.com/guidebee/jobseeker/internal/geo"
+)
+
+// classifyColumns are the columns Classify fills in.
+var classifyColumns = []string{"work_arrangement", "seniority", "schedule", "clearance", "citizenship_required",
+	"city", "state", "latitude", "longitude", "classifier_version"}
+
+// Classify fills in the job's work arrangement, seniority, schedule,
+// clearance and citizenship fields from its ad (see classify.Classify), and
+// normalises its location to a city and state. Fields the ad doesn't
+// indicate are left empty.
+func (j *Job) Classify() {
+	r := classify.Classify(classify.Ad{
+		Title:        j.Title,
+		Location:     j.Location,
+		WorkType:     j.WorkType,
+		Description:  j.Description,
+		Requirements: j.Requirements,
+	})
+	j.WorkArrangement = r.WorkArrangement
+	j.Seniority = r.Seniority
+	j.Schedule = r.Schedule
+	j.Clearance = r.Clearance
+	j.CitizenshipRequired = r.CitizenshipRequired
+
+	j.City, j.State, j.Latitude, j.Longitude = "", "", 0, 0
+	if p, ok := geo.Lookup(j.Location); ok {
+		j.City, j.State, j.Latitude, j.Longitude = p.City, p.State, p.Lat, p.Lon
+	}
+
+	j.ClassifierVersion = classify.Version
+}
+
+// Place returns where the job is, from its normalised location, or false if
+// the location is unknown.
+func (j *Job) Place() (geo.Place, bool) {
+	if j.City == "" {
+		return geo.Place{}, false
+	}
+	return geo.Place{Name: j.Location, State: j.State, City: j.City, Lat: j.Latitude, Lon: j.Longitude}, true
+}
+
+// BackfillClassifications classifies jobs stored before the classifier
+// existed or last changed. It returns the number of jobs updated.
+func BackfillClassifications() (int, error) {
+	var jobs []Job
+	err := GetDB().
+		Select("id", "title", "location", "work_type", "description", "requirements").
+		Where("classifier_version < ? OR classifier_version IS NULL", classify.Version).
+		Find(&jobs).Error
+	if err != nil {
+		return 0, fmt.Errorf("failed to load jobs to classify: %w", err)
+	}
+	for i := range jobs {
+		jobs[i].Classify()
+		if err := GetDB().Model(&jobs[i]).Select(classifyColumns).Updates(&jobs[i]).Error; err != nil {
+			return i, fmt.Errorf("failed to save classification of job %d: %w", jobs[i].ID, err)
+		}
+	}
+	return len(jobs), nil
+}
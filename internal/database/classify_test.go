@@ -0,0 +1,48 @@
+package database
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/guidebee/jobseeker/internal/classify"
+)
+
+func TestClassifyLocation(t *testing.T) {
+	job := Job{Location: "Docklands, Melbourne VIC 3008"}
+	job.Classify()
+	if job.City != "Melbourne" || job.State != "VIC" || job.Latitude == 0 {
+		t.Errorf("got %q %q %v,%v", job.City, job.State, job.Latitude, job.Longitude)
+	}
+	if _, ok := job.Place(); !ok {
+		t.Error("Place() found nothing for a located job")
+	}
+
+	job = Job{Location: "Australia"}
+	job.Classify()
+	if _, ok := job.Place(); ok || job.City != "" {
+		t.Errorf("a country-wide job got a place: %q", job.City)
+	}
+}
+
+func TestBackfillClassifications(t *testing.T) {
+	if err := InitDB(filepath.Join(t.TempDir(), "classify.db")); err != nil {
+		t.Fatalf("InitDB failed: %v", err)
+	}
+
+	job := Job{UserID: 1, ExternalID: "seek-1", Title: "Senior Go Engineer", Location: "Remote"}
+	if err := GetDB().Create(&job).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	if n, err := BackfillClassifications(); err != nil || n != 1 {
+		t.Fatalf("BackfillClassifications = %d, %v, want 1 job", n, err)
+	}
+	var stored Job
+	GetDB().First(&stored, job.ID)
+	if stored.WorkArrangement != classify.ArrangementRemote || stored.Seniority != classify.SenioritySenior || stored.ClassifierVersion != classify.Version {
+		t.Errorf("stored = %q %q v%d", stored.WorkArrangement, stored.Seniority, stored.ClassifierVersion)
+	}
+	if n, _ := BackfillClassifications(); n != 0 {
+		t.Errorf("a second backfill classified %d jobs again", n)
+	}
+}
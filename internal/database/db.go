@@ -26,14 +26,36 @@ func InitDB(dbPath string) error {
 		return fmt.Errorf("failed to connect to database: %w", err)
 	}
 
+	// SQLite allows one writer at a time; a single connection makes concurrent
+	// scan workers queue up instead of failing with "database is locked"
+	sqlDB, err := DB.DB()
+	if err != nil {
+		return fmt.Errorf("failed to get database handle: %w", err)
+	}
+	sqlDB.SetMaxOpenConns(1)
+
 	// AutoMigrate creates tables based on your struct definitions
 	// This is like running SQL CREATE TABLE statements
 	// Order matters: User must be created before models with foreign keys
-	err = DB.AutoMigrate(&User{}, &Job{}, &Application{}, &ProfileData{})
+	err = DB.AutoMigrate(&User{}, &Job{}, &Application{}, &ProfileData{}, &ScanRun{}, &ScanResult{}, &JobRevision{}, &AICacheEntry{})
 	if err != nil {
 		return fmt.Errorf("failed to migrate database: %w", err)
 	}
 
+	// Jobs saved before pay was parsed get their salary columns filled in once
+	if n, err := BackfillSalaries(); err != nil {
+		return err
+	} else if n > 0 {
+		log.Printf("Parsed salaries of %d jobs", n)
+	}
+
+	// Jobs saved before the classifier existed, or last changed, are classified again
+	if n, err := BackfillClassifications(); err != nil {
+		return err
+	} else if n > 0 {
+		log.Printf("Classified %d jobs", n)
+	}
+
 	log.Println("Database initialized successfully")
 	return nil
 }
@@ -56,6 +56,38 @@ type Job struct {
 	JobType     string `gorm:"index"`        // "contract", "permanent", "unknown"
 	Description string `gorm:"type:text"`
 	Requirements string `gorm:"type:text"`
+	WorkType       string     // Board's own work type, e.g. SEEK "Contract/Temp"
+	Classification string     // Board's category, e.g. SEEK "Developers/Programmers (ICT)"
+	ListedAt       *time.Time // When the board says the job was listed (not our discovery time)
+	Applicants     *int       // Applicants so far as the board shows it (LinkedIn); nil if not shown
+	LastSeenAt     *time.Time `gorm:"index"` // Last time a scan returned the job
+	ExpiredAt      *time.Time `gorm:"index"` // Set once the listing is confirmed closed
+	ClusterID      uint       `gorm:"index"` // ID of the first job of the same role on any board; 0 until clustered
+
+	// Pay parsed from Salary (see internal/salary); SalaryPeriod is "" until
+	// parsed and "unknown" when Salary has no usable amount
+	SalaryMin           float64
+	SalaryMax           float64
+	SalaryCurrency      string
+	SalaryPeriod        string  // "hour", "day", "week", "month", "year" or "unknown"
+	SalarySuperIncluded bool    // The amount is a package including super
+	SalaryAnnual        float64 `gorm:"index"` // Yearly equivalent excluding super, 0 if unknown
+	SalaryParserVersion int     // Version of the rules that parsed Salary; 0 if never parsed
+
+	// Classification from the ad's text (see Classify); "" where the ad doesn't say
+	WorkArrangement     string `gorm:"index"` // "remote", "hybrid" or "onsite"
+	Seniority           string `gorm:"index"` // "graduate", "junior", "mid", "senior", "lead", "principal" or "executive"
+	Schedule            string // "full-time", "part-time" or "casual"
+	Clearance           string `gorm:"index"` // Security clearance required: "baseline", "nv1", "nv2" or "pv"
+	CitizenshipRequired bool   `gorm:"index"` // Australian citizens only, including every cleared role
+	ClassifierVersion   int    // Version of the rules that classified the job; 0 if never classified
+
+	// Location normalised against the gazetteer in internal/geo; empty and 0
+	// when Location names no known place (e.g. "Australia")
+	City      string `gorm:"index"` // Metro area, e.g. "Melbourne" for "Docklands VIC"
+	State     string // e.g. "VIC"
+	Latitude  float64
+	Longitude float64
 
 	// Analysis results from Claude
 	MatchScore       int        // 0-100
@@ -64,6 +96,7 @@ type Job struct {
 	AnalysisPros     string     `gorm:"type:text"` // Pros as JSON array
 	AnalysisCons     string     `gorm:"type:text"` // Cons as JSON array
 	ResumeUsed       string     // Which resume was used for analysis
+	AnalysisModel    string     // Which model gave the score, e.g. "minimax/MiniMax-M2.5"
 	IsAnalyzed       bool       `gorm:"index"`
 	AnalyzedAt       *time.Time
 
@@ -76,6 +109,29 @@ type Job struct {
 	EmailedAt *time.Time `gorm:"index"` // Set when job is included in a daily email digest
 }
 
+// Job statuses. Analysis sets StatusRecommended or StatusRejected; the
+// approved and applied statuses are only ever set by the user, so
+// re-analysing a job keeps them.
+const (
+	StatusDiscovered  = "discovered"
+	StatusRecommended = "recommended"
+	StatusApproved    = "approved"
+	StatusApplied     = "applied"
+	StatusRejected    = "rejected"
+)
+
+// JobRevision records one change to a stored job noticed by a later scan:
+// an edited field, or the listing closing or being re-posted.
+type JobRevision struct {
+	ID        uint `gorm:"primarykey"`
+	CreatedAt time.Time
+
+	JobID    uint   `gorm:"index;not null"`
+	Field    string // "title", "company", "location", "salary", or "listing" for closed/reposted
+	OldValue string `gorm:"type:text"`
+	NewValue string `gorm:"type:text"`
+}
+
 // Application tracks submitted job applications
 type Application struct {
 	ID          uint           `gorm:"primarykey"`
@@ -125,9 +181,74 @@ type ProfileData struct {
 
 	// Search keywords extracted from resumes (JSON array)
 	SearchKeywords string `gorm:"type:text"`
+	// Fingerprint of the resumes SearchKeywords came from ("" if they came
+	// from LinkedIn); scan re-extracts when the resumes no longer match
+	ResumesHash string
 
 	// Metadata
 	ResumesCount   int
 	LastInitAt     time.Time
 	InitVersion    string // Track init schema version
 }
+
+// ScanRun records one 'jobseeker scan' invocation
+type ScanRun struct {
+	ID        uint `gorm:"primarykey"`
+	CreatedAt time.Time
+	UpdatedAt time.Time
+
+	// User ownership
+	UserID uint `gorm:"index;not null"`
+	User   User `gorm:"foreignKey:UserID"`
+
+	StartedAt  time.Time  `gorm:"index"`
+	FinishedAt *time.Time // nil while running, or if the scan was interrupted
+	Boards     string     // Comma-separated board names, e.g. "linkedin,seek"
+
+	// Totals over all results, filled in when the run finishes
+	Targets    int
+	JobsFound  int
+	NewJobs    int
+	Duplicates int
+	Errors     int // Targets that failed
+
+	Results []ScanResult `gorm:"foreignKey:ScanRunID"`
+}
+
+// ScanResult records the outcome of one search target within a scan run
+type ScanResult struct {
+	ID        uint `gorm:"primarykey"`
+	CreatedAt time.Time
+
+	ScanRunID uint   `gorm:"index;not null"`
+	Board     string `gorm:"index"`
+	SearchURL string // Search URL, company slug or careers site name
+
+	StartedAt  time.Time
+	FinishedAt time.Time
+
+	JobsFound  int    // Jobs the board returned
+	NewJobs    int    // Jobs inserted
+	Duplicates int    // Jobs that were already stored
+	Error      string `gorm:"type:text"` // Empty on success
+	Broken     bool   // The page no longer matched the scraper's selectors
+}
+
+// AICacheEntry is an AI provider's reply to a prompt, kept so that the same
+// prompt isn't sent to the same model twice (see AICache)
+type AICacheEntry struct {
+	ID        uint `gorm:"primarykey"`
+	CreatedAt time.Time
+
+	// SHA-256 of the model, prompt template version and filled-in prompt
+	Key      string `gorm:"uniqueIndex;not null"`
+	Model    string `gorm:"index"` // "provider/model", e.g. "minimax/MiniMax-M2.5"
+	Template string `gorm:"index"` // Prompt template and version, e.g. "job-analysis/v1"
+
+	Response     string `gorm:"type:text"`
+	InputTokens  int
+	OutputTokens int
+	Hits         int // Times the reply was reused
+
+	ExpiresAt time.Time `gorm:"index"`
+}
@@ -0,0 +1,36 @@
+package database
+
+import (
+	"errors"
+	"fmt"
+
+	"gorm.io/gorm"
+)
+
+// GetProfileData returns the user's cached profile data, or nil if init has
+// not stored any yet.
+func GetProfileData(userID uint) (*ProfileData, error) {
+	var data ProfileData
+	err := GetDB().Where("user_id = ?", userID).First(&data).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to load profile data: %w", err)
+	}
+	return &data, nil
+}
+
+// SaveSearchKeywords stores the user's search keywords (JSON) and the hash of
+// the resumes they were extracted from, creating the profile data if init
+// hasn't.
+func SaveSearchKeywords(userID uint, keywordsJSON, resumesHash string) error {
+	data := ProfileData{UserID: userID}
+	err := GetDB().Where(ProfileData{UserID: userID}).
+		Assign(map[string]any{"search_keywords": keywordsJSON, "resumes_hash": resumesHash}).
+		FirstOrCreate(&data).Error
+	if err != nil {
+		return fmt.Errorf("failed to save search keywords: %w", err)
+	}
+	return nil
+}
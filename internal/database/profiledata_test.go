@@ -0,0 +1,37 @@
+package database
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestSaveSearchKeywords(t *testing.T) {
+	if err := InitDB(filepath.Join(t.TempDir(), "keywords.db")); err != nil {
+		t.Fatalf("InitDB failed: %v", err)
+	}
+
+	if data, err := GetProfileData(1); err != nil || data != nil {
+		t.Fatalf("GetProfileData before init = %v, %v; want nil", data, err)
+	}
+
+	// Saving keywords before init creates the profile data
+	if err := SaveSearchKeywords(1, `{"roles":["Go Engineer"]}`, "abc"); err != nil {
+		t.Fatalf("SaveSearchKeywords failed: %v", err)
+	}
+	if err := SaveSearchKeywords(1, `{}`, ""); err != nil {
+		t.Fatalf("SaveSearchKeywords failed: %v", err)
+	}
+
+	data, err := GetProfileData(1)
+	if err != nil || data == nil {
+		t.Fatalf("GetProfileData = %v, %v", data, err)
+	}
+	if data.SearchKeywords != `{}` || data.ResumesHash != "" {
+		t.Errorf("stored keywords %q hash %q, want the second save", data.SearchKeywords, data.ResumesHash)
+	}
+	var count int64
+	GetDB().Model(&ProfileData{}).Count(&count)
+	if count != 1 {
+		t.Errorf("%d profile data rows, want 1", count)
+	}
+}
@@ -0,0 +1,158 @@
+package database
+
+import (
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Listing values recorded in the "listing" field of a JobRevision.
+const (
+	ListingOpen     = "open"
+	ListingClosed   = "closed"
+	ListingReposted = "reposted"
+)
+
+// IsExpired reports whether the job's listing has been confirmed closed.
+func (j *Job) IsExpired() bool {
+	return j.ExpiredAt != nil
+}
+
+// DiffJob compares a stored job with the same job from a new scan and
+// returns a revision for every tracked field that changed. Fields the scan
+// left empty are not compared, since search results carry less than the
+// stored job's detail page did.
+func DiffJob(stored, scanned *Job) []JobRevision {
+	var revs []JobRevision
+	for _, f := range []struct {
+		name     string
+		old, new string
+	}{
+		{"title", stored.Title, scanned.Title},
+		{"company", stored.Company, scanned.Company},
+		{"location", stored.Location, scanned.Location},
+		{"salary", stored.Salary, scanned.Salary},
+	} {
+		if f.new != "" && f.new != f.old {
+			revs = append(revs, JobRevision{JobID: stored.ID, Field: f.name, OldValue: f.old, NewValue: f.new})
+		}
+	}
+	return revs
+}
+
+// RefreshJob updates a stored job from a new scan of it: changed fields are
+// copied over and recorded as revisions, a closed listing that shows up again
+// is marked re-posted, the listing date and applicant count are brought up to
+// date, and LastSeenAt is set. It returns the revisions made.
+func RefreshJob(stored, scanned *Job) ([]JobRevision, error) {
+	revs := DiffJob(stored, scanned)
+	for _, rev := range revs {
+		switch rev.Field {
+		case "title":
+			stored.Title = rev.NewValue
+		case "company":
+			stored.Company = rev.NewValue
+		case "location":
+			stored.Location = rev.NewValue
+		case "salary":
+			stored.Salary = rev.NewValue
+			stored.ParseSalary()
+		}
+	}
+	if len(revs) > 0 {
+		stored.Classify()
+	}
+	// Keep the latest applicant count; a re-posted listing has a new date
+	if scanned.Applicants != nil {
+		stored.Applicants = scanned.Applicants
+	}
+	if scanned.ListedAt != nil && (stored.ListedAt == nil || stored.ExpiredAt != nil) {
+		stored.ListedAt = scanned.ListedAt
+	}
+	if stored.ExpiredAt != nil {
+		stored.ExpiredAt = nil
+		revs = append(revs, JobRevision{JobID: stored.ID, Field: "listing", OldValue: ListingClosed, NewValue: ListingReposted})
+	}
+
+	now := time.Now()
+	stored.LastSeenAt = &now
+
+	err := GetDB().Transaction(func(tx *gorm.DB) error {
+		if len(revs) > 0 {
+			if err := tx.Create(&revs).Error; err != nil {
+				return err
+			}
+		}
+		columns := []string{"title", "company", "location", "salary", "listed_at", "applicants", "expired_at", "last_seen_at"}
+		columns = append(append(columns, salaryColumns...), classifyColumns...)
+		return tx.Model(stored).Select(columns).Updates(stored).Error
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to update job %s: %w", stored.ExternalID, err)
+	}
+	return revs, nil
+}
+
+// MarkExpired records that a job's listing has closed.
+func MarkExpired(job *Job) error {
+	now := time.Now()
+	err := GetDB().Transaction(func(tx *gorm.DB) error {
+		rev := JobRevision{JobID: job.ID, Field: "listing", OldValue: ListingOpen, NewValue: ListingClosed}
+		if err := tx.Create(&rev).Error; err != nil {
+			return err
+		}
+		return tx.Model(job).Update("expired_at", now).Error
+	})
+	if err != nil {
+		return fmt.Errorf("failed to expire job %s: %w", job.ExternalID, err)
+	}
+	job.ExpiredAt = &now
+	return nil
+}
+
+// MarkSeen records that a job's listing was found to be still open.
+func MarkSeen(job *Job) error {
+	now := time.Now()
+	if err := GetDB().Model(job).Update("last_seen_at", now).Error; err != nil {
+		return fmt.Errorf("failed to update job %s: %w", job.ExternalID, err)
+	}
+	job.LastSeenAt = &now
+	return nil
+}
+
+// StaleJobs returns up to limit open jobs from the given sources that no scan
+// has returned since before, oldest first. These are candidates for a
+// closure check.
+func StaleJobs(userID uint, sources []string, before time.Time, limit int) ([]Job, error) {
+	var jobs []Job
+	query := GetDB().
+		Where("user_id = ? AND source IN ? AND expired_at IS NULL", userID, sources).
+		Where("COALESCE(last_seen_at, created_at) < ?", before).
+		Order("COALESCE(last_seen_at, created_at)")
+	if limit > 0 {
+		query = query.Limit(limit)
+	}
+	if err := query.Find(&jobs).Error; err != nil {
+		return nil, fmt.Errorf("failed to load stale jobs: %w", err)
+	}
+	return jobs, nil
+}
+
+// GetRevisions returns the revisions of the given jobs, oldest first, keyed
+// by job ID.
+func GetRevisions(jobIDs []uint) (map[uint][]JobRevision, error) {
+	byJob := make(map[uint][]JobRevision)
+	if len(jobIDs) == 0 {
+		return byJob, nil
+	}
+
+	var revs []JobRevision
+	if err := GetDB().Where("job_id IN ?", jobIDs).Order("id").Find(&revs).Error; err != nil {
+		return nil, fmt.Errorf("failed to load job revisions: %w", err)
+	}
+	for _, rev := range revs {
+		byJob[rev.JobID] = append(byJob[rev.JobID], rev)
+	}
+	return byJob, nil
+}
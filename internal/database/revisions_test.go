@@ -0,0 +1,73 @@
+package database
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestStaleJobs(t *testing.T) {
+	if err := InitDB(filepath.Join(t.TempDir(), "stale.db")); err != nil {
+		t.Fatalf("InitDB failed: %v", err)
+	}
+
+	now := time.Now()
+	weekAgo, dayAgo := now.Add(-7*24*time.Hour), now.Add(-24*time.Hour)
+	jobs := []Job{
+		{UserID: 1, ExternalID: "seek-1", Source: "seek", LastSeenAt: &weekAgo},
+		{UserID: 1, ExternalID: "seek-2", Source: "seek", LastSeenAt: &dayAgo},
+		{UserID: 1, ExternalID: "seek-3", Source: "seek", LastSeenAt: &weekAgo, ExpiredAt: &dayAgo},
+		{UserID: 1, ExternalID: "indeed-4", Source: "indeed", LastSeenAt: &weekAgo},
+		{UserID: 2, ExternalID: "seek-5", Source: "seek", LastSeenAt: &weekAgo},
+	}
+	if err := GetDB().Create(&jobs).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	stale, err := StaleJobs(1, []string{"seek"}, now.Add(-3*24*time.Hour), 10)
+	if err != nil {
+		t.Fatalf("StaleJobs failed: %v", err)
+	}
+	if len(stale) != 1 || stale[0].ExternalID != "seek-1" {
+		t.Fatalf("StaleJobs = %+v, want only seek-1", stale)
+	}
+
+	if err := MarkSeen(&stale[0]); err != nil {
+		t.Fatalf("MarkSeen failed: %v", err)
+	}
+	if stale, _ := StaleJobs(1, []string{"seek"}, now.Add(-3*24*time.Hour), 10); len(stale) != 0 {
+		t.Errorf("a job seen again is still stale: %+v", stale)
+	}
+}
+
+func TestRefreshJobListingSignals(t *testing.T) {
+	if err := InitDB(filepath.Join(t.TempDir(), "refresh.db")); err != nil {
+		t.Fatalf("InitDB failed: %v", err)
+	}
+
+	listed := time.Date(2025, 3, 5, 0, 0, 0, 0, time.UTC)
+	job := Job{UserID: 1, ExternalID: "linkedin-1", Source: "linkedin", Title: "Go Engineer"}
+	if err := GetDB().Create(&job).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	applicants := 40
+	if _, err := RefreshJob(&job, &Job{ListedAt: &listed, Applicants: &applicants}); err != nil {
+		t.Fatalf("RefreshJob failed: %v", err)
+	}
+	var stored Job
+	GetDB().First(&stored, job.ID)
+	if stored.ListedAt == nil || !stored.ListedAt.Equal(listed) || stored.Applicants == nil || *stored.Applicants != 40 {
+		t.Errorf("stored listed_at=%v applicants=%v", stored.ListedAt, stored.Applicants)
+	}
+
+	// A later scan of an open listing keeps the original date
+	later := listed.Add(48 * time.Hour)
+	if _, err := RefreshJob(&stored, &Job{ListedAt: &later}); err != nil {
+		t.Fatalf("RefreshJob failed: %v", err)
+	}
+	GetDB().First(&stored, job.ID)
+	if !stored.ListedAt.Equal(listed) || *stored.Applicants != 40 {
+		t.Errorf("second scan changed listed_at=%v applicants=%v", stored.ListedAt, *stored.Applicants)
+	}
+}
@@ -0,0 +1,44 @@
+package database
+
+import (
+	"fmt"
+
+	"github.com/guidebee/jobseeker/internal/salary"
+)
+
+// SalaryUnknown is the SalaryPeriod of a job whose Salary text has no
+// amount the parser understands.
+const SalaryUnknown = "unknown"
+
+// salaryColumns are the columns ParseSalary fills in.
+var salaryColumns = []string{"salary_min", "salary_max", "salary_currency", "salary_period", "salary_super_included", "salary_annual", "salary_parser_version"}
+
+// ParseSalary fills in the job's structured pay fields from its Salary text.
+func (j *Job) ParseSalary() {
+	j.SalaryParserVersion = salary.Version
+	pay, ok := salary.Parse(j.Salary)
+	if !ok {
+		j.SalaryMin, j.SalaryMax, j.SalaryCurrency = 0, 0, ""
+		j.SalaryPeriod, j.SalarySuperIncluded, j.SalaryAnnual = SalaryUnknown, false, 0
+		return
+	}
+	j.SalaryMin, j.SalaryMax, j.SalaryCurrency = pay.Min, pay.Max, pay.Currency
+	j.SalaryPeriod, j.SalarySuperIncluded, j.SalaryAnnual = pay.Period, pay.SuperIncluded, pay.Annual()
+}
+
+// BackfillSalaries parses the Salary text of jobs stored before the pay
+// columns existed or the parser last changed. It returns the number of jobs updated.
+func BackfillSalaries() (int, error) {
+	var jobs []Job
+	err := GetDB().Select("id", "salary").Where("salary_parser_version < ? OR salary_parser_version IS NULL", salary.Version).Find(&jobs).Error
+	if err != nil {
+		return 0, fmt.Errorf("failed to load jobs to parse salaries: %w", err)
+	}
+	for i := range jobs {
+		jobs[i].ParseSalary()
+		if err := GetDB().Model(&jobs[i]).Select(salaryColumns).Updates(&jobs[i]).Error; err != nil {
+			return i, fmt.Errorf("failed to save salary of job %d: %w", jobs[i].ID, err)
+		}
+	}
+	return len(jobs), nil
+}
@@ -0,0 +1,53 @@
+package database
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/guidebee/jobseeker/internal/salary"
+)
+
+func TestBackfillSalaries(t *testing.T) {
+	if err := InitDB(filepath.Join(t.TempDir(), "salary.db")); err != nil {
+		t.Fatalf("InitDB failed: %v", err)
+	}
+
+	jobs := []Job{
+		{UserID: 1, ExternalID: "seek-1", Salary: "$900 - $1,050 per day + super"},
+		{UserID: 1, ExternalID: "seek-2", Salary: "Competitive"},
+	}
+	if err := GetDB().Create(&jobs).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	if n, err := BackfillSalaries(); err != nil || n != 2 {
+		t.Fatalf("BackfillSalaries = %d, %v, want 2 jobs", n, err)
+	}
+	var stored []Job
+	GetDB().Order("id").Find(&stored)
+	if stored[0].SalaryPeriod != "day" || stored[0].SalaryMax != 1050 || stored[0].SalaryAnnual != 231000 {
+		t.Errorf("seek-1 pay = %s %.0f %.0f", stored[0].SalaryPeriod, stored[0].SalaryMax, stored[0].SalaryAnnual)
+	}
+	if stored[1].SalaryPeriod != SalaryUnknown {
+		t.Errorf("seek-2 period = %q, want %q", stored[1].SalaryPeriod, SalaryUnknown)
+	}
+	if n, _ := BackfillSalaries(); n != 0 {
+		t.Errorf("a second backfill parsed %d jobs again", n)
+	}
+
+	// Salaries parsed by older rules are parsed again
+	GetDB().Model(&stored[0]).Update("salary_parser_version", salary.Version-1)
+	if n, _ := BackfillSalaries(); n != 1 {
+		t.Errorf("backfill after a parser change parsed %d jobs, want 1", n)
+	}
+
+	// A changed salary is parsed again when a scan refreshes the job
+	if _, err := RefreshJob(&stored[1], &Job{Salary: "$150k - $160k package"}); err != nil {
+		t.Fatalf("RefreshJob failed: %v", err)
+	}
+	var refreshed Job
+	GetDB().First(&refreshed, stored[1].ID)
+	if refreshed.SalaryPeriod != "year" || !refreshed.SalarySuperIncluded || refreshed.SalaryMin != 150000 {
+		t.Errorf("refreshed pay = %+v", refreshed)
+	}
+}
@@ -0,0 +1,141 @@
+package database
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// StartScanRun records the start of a scan over the given boards.
+func StartScanRun(userID uint, boards []string) (*ScanRun, error) {
+	run := &ScanRun{
+		UserID:    userID,
+		StartedAt: time.Now(),
+		Boards:    strings.Join(boards, ","),
+	}
+	if err := GetDB().Create(run).Error; err != nil {
+		return nil, fmt.Errorf("failed to record scan run: %w", err)
+	}
+	return run, nil
+}
+
+// RecordScanResult stores the outcome of one search target of a run.
+func RecordScanResult(result *ScanResult) error {
+	if err := GetDB().Create(result).Error; err != nil {
+		return fmt.Errorf("failed to record scan result: %w", err)
+	}
+	return nil
+}
+
+// FinishScanRun marks a run as finished and stores the totals of its results.
+func FinishScanRun(run *ScanRun) error {
+	db := GetDB()
+
+	var totals struct {
+		Targets    int
+		JobsFound  int
+		NewJobs    int
+		Duplicates int
+		Errors     int
+	}
+	err := db.Model(&ScanResult{}).
+		Select(`COUNT(*) AS targets,
+			COALESCE(SUM(jobs_found), 0) AS jobs_found,
+			COALESCE(SUM(new_jobs), 0) AS new_jobs,
+			COALESCE(SUM(duplicates), 0) AS duplicates,
+			COALESCE(SUM(CASE WHEN error <> '' THEN 1 ELSE 0 END), 0) AS errors`).
+		Where("scan_run_id = ?", run.ID).
+		Scan(&totals).Error
+	if err != nil {
+		return fmt.Errorf("failed to total scan results: %w", err)
+	}
+
+	now := time.Now()
+	run.FinishedAt = &now
+	run.Targets = totals.Targets
+	run.JobsFound = totals.JobsFound
+	run.NewJobs = totals.NewJobs
+	run.Duplicates = totals.Duplicates
+	run.Errors = totals.Errors
+
+	if err := db.Save(run).Error; err != nil {
+		return fmt.Errorf("failed to update scan run: %w", err)
+	}
+	return nil
+}
+
+// GetRecentScanRuns returns the user's latest scan runs with their results.
+func GetRecentScanRuns(userID uint, limit int) ([]ScanRun, error) {
+	var runs []ScanRun
+	query := GetDB().Preload("Results").Where("user_id = ?", userID).Order("started_at DESC")
+	if limit > 0 {
+		query = query.Limit(limit)
+	}
+	if err := query.Find(&runs).Error; err != nil {
+		return nil, fmt.Errorf("failed to load scan runs: %w", err)
+	}
+	return runs, nil
+}
+
+// GetScanRun returns one of the user's scan runs with its results.
+func GetScanRun(userID, runID uint) (*ScanRun, error) {
+	var run ScanRun
+	err := GetDB().Preload("Results", func(db *gorm.DB) *gorm.DB {
+		return db.Order("board, id")
+	}).Where("user_id = ?", userID).First(&run, runID).Error
+	if err != nil {
+		return nil, fmt.Errorf("scan run %d not found: %w", runID, err)
+	}
+	return &run, nil
+}
+
+// ZeroYield returns the successful results that found no jobs at all. A
+// search that used to return jobs and suddenly returns none usually means
+// the board changed its markup.
+func (r *ScanRun) ZeroYield() []ScanResult {
+	var zero []ScanResult
+	for _, res := range r.Results {
+		if res.Error == "" && res.JobsFound == 0 {
+			zero = append(zero, res)
+		}
+	}
+	return zero
+}
+
+// BrokenBoards returns the boards, in result order, with at least one search
+// whose page no longer matched the scraper's selectors.
+func (r *ScanRun) BrokenBoards() []string {
+	var boards []string
+	seen := make(map[string]bool)
+	for _, res := range r.Results {
+		if res.Broken && !seen[res.Board] {
+			seen[res.Board] = true
+			boards = append(boards, res.Board)
+		}
+	}
+	return boards
+}
+
+// MedianYield returns the median number of jobs the last lastN successful
+// scans of a search found, or 0 when the search has no history.
+func MedianYield(userID uint, board, searchURL string, lastN int) (int, error) {
+	var yields []int
+	err := GetDB().Model(&ScanResult{}).
+		Joins("JOIN scan_runs ON scan_runs.id = scan_results.scan_run_id").
+		Where("scan_runs.user_id = ? AND scan_results.board = ? AND scan_results.search_url = ? AND scan_results.error = ''",
+			userID, board, searchURL).
+		Order("scan_results.id DESC").
+		Limit(lastN).
+		Pluck("scan_results.jobs_found", &yields).Error
+	if err != nil {
+		return 0, fmt.Errorf("failed to load scan history: %w", err)
+	}
+	if len(yields) == 0 {
+		return 0, nil
+	}
+	sort.Ints(yields)
+	return yields[len(yields)/2], nil
+}
@@ -0,0 +1,88 @@
+package database
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestScanRunTotals(t *testing.T) {
+	if err := InitDB(filepath.Join(t.TempDir(), "scans.db")); err != nil {
+		t.Fatalf("InitDB failed: %v", err)
+	}
+
+	run, err := StartScanRun(1, []string{"linkedin", "seek"})
+	if err != nil {
+		t.Fatalf("StartScanRun failed: %v", err)
+	}
+
+	now := time.Now()
+	for _, r := range []ScanResult{
+		{Board: "seek", SearchURL: "https://www.seek.com.au/jobs?keywords=go", JobsFound: 22, NewJobs: 5, Duplicates: 17},
+		{Board: "seek", SearchURL: "https://www.seek.com.au/jobs?keywords=rust", JobsFound: 0},
+		{Board: "linkedin", SearchURL: "https://www.linkedin.com/jobs/search/?keywords=go", Error: "linkedin: visit failed"},
+	} {
+		r.ScanRunID = run.ID
+		r.StartedAt, r.FinishedAt = now, now
+		if err := RecordScanResult(&r); err != nil {
+			t.Fatalf("RecordScanResult failed: %v", err)
+		}
+	}
+
+	if err := FinishScanRun(run); err != nil {
+		t.Fatalf("FinishScanRun failed: %v", err)
+	}
+
+	runs, err := GetRecentScanRuns(1, 10)
+	if err != nil || len(runs) != 1 {
+		t.Fatalf("GetRecentScanRuns = %v, %v", runs, err)
+	}
+	got := runs[0]
+	if got.FinishedAt == nil || got.Targets != 3 || got.JobsFound != 22 || got.NewJobs != 5 || got.Duplicates != 17 || got.Errors != 1 {
+		t.Errorf("unexpected totals: %+v", got)
+	}
+	if zero := got.ZeroYield(); len(zero) != 1 || zero[0].SearchURL != "https://www.seek.com.au/jobs?keywords=rust" {
+		t.Errorf("ZeroYield = %+v", zero)
+	}
+
+	if _, err := GetScanRun(2, run.ID); err == nil {
+		t.Error("expected another user's scan run to be hidden")
+	}
+}
+
+func TestMedianYield(t *testing.T) {
+	if err := InitDB(filepath.Join(t.TempDir(), "yield.db")); err != nil {
+		t.Fatalf("InitDB failed: %v", err)
+	}
+
+	const search = "https://www.seek.com.au/jobs?keywords=go"
+	for _, r := range []ScanResult{
+		{Board: "seek", SearchURL: search, JobsFound: 20},
+		{Board: "seek", SearchURL: search, JobsFound: 2},
+		{Board: "seek", SearchURL: search, JobsFound: 18},
+		{Board: "seek", SearchURL: search, Error: "failed to visit URL", Broken: true},
+		{Board: "indeed", SearchURL: search, JobsFound: 99},
+	} {
+		run, err := StartScanRun(1, []string{r.Board})
+		if err != nil {
+			t.Fatalf("StartScanRun failed: %v", err)
+		}
+		r.ScanRunID = run.ID
+		if err := RecordScanResult(&r); err != nil {
+			t.Fatalf("RecordScanResult failed: %v", err)
+		}
+	}
+
+	if got, err := MedianYield(1, "seek", search, 10); err != nil || got != 18 {
+		t.Errorf("MedianYield = %d, %v; want 18", got, err)
+	}
+	if got, _ := MedianYield(1, "seek", search, 2); got != 18 {
+		t.Errorf("MedianYield over the last 2 scans = %d, want 18", got)
+	}
+	if got, _ := MedianYield(2, "seek", search, 10); got != 0 {
+		t.Errorf("another user's history leaked: %d", got)
+	}
+	if got, _ := MedianYield(1, "seek", "https://www.seek.com.au/jobs?keywords=rust", 10); got != 0 {
+		t.Errorf("search without history = %d, want 0", got)
+	}
+}
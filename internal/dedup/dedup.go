@@ -0,0 +1,320 @@
+// Package dedup finds copies of the same role: one job posted on several
+// boards, or re-posted by recruitment agencies.
+package dedup
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/guidebee/jobseeker/internal/database"
+	"gorm.io/gorm"
+)
+
+// Thresholds for treating two jobs as the same role.
+const (
+	// minTitleSimilarity is the token overlap two titles need to be compared at all.
+	minTitleSimilarity = 0.75
+	// minSameCompanyDescription is the description overlap below which two
+	// ads from one employer are separate openings with the same title.
+	minSameCompanyDescription = 0.3
+	// minDescriptionSimilarity is the description overlap that makes ads from
+	// different advertisers (typically agencies) the same role.
+	minDescriptionSimilarity = 0.6
+)
+
+// Stats describes the outcome of a clustering pass.
+type Stats struct {
+	Jobs       int // jobs considered
+	Clusters   int // clusters with more than one job
+	Duplicates int // jobs that are a copy of an earlier job
+	Updated    int // jobs whose ClusterID changed
+}
+
+// ClusterUserJobs clusters a user's open jobs and stores each job's
+// ClusterID: the ID of the earliest job in its cluster. Jobs whose listing
+// has closed keep the cluster they had, so the pass grows with the listings
+// still open rather than with every job ever stored.
+func ClusterUserJobs(userID uint) (Stats, error) {
+	db := database.GetDB()
+
+	var jobs []database.Job
+	err := db.Select("id", "cluster_id", "title", "company", "location", "description").
+		Where("user_id = ? AND expired_at IS NULL", userID).
+		Order("id").
+		Find(&jobs).Error
+	if err != nil {
+		return Stats{}, fmt.Errorf("failed to load jobs: %w", err)
+	}
+
+	clusters := Cluster(jobs)
+	stats := Stats{Jobs: len(jobs)}
+	sizes := make(map[uint]int)
+	err = db.Transaction(func(tx *gorm.DB) error {
+		for _, job := range jobs {
+			cid := clusters[job.ID]
+			sizes[cid]++
+			if cid != job.ID {
+				stats.Duplicates++
+			}
+			if cid == job.ClusterID {
+				continue
+			}
+			if err := tx.Model(&database.Job{}).Where("id = ?", job.ID).Update("cluster_id", cid).Error; err != nil {
+				return fmt.Errorf("failed to update cluster of job %d: %w", job.ID, err)
+			}
+			stats.Updated++
+		}
+		return nil
+	})
+	if err != nil {
+		return Stats{}, err
+	}
+	for _, n := range sizes {
+		if n > 1 {
+			stats.Clusters++
+		}
+	}
+	return stats, nil
+}
+
+// Cluster groups jobs that are copies of the same role and returns the
+// cluster ID of every job, keyed by job ID. A cluster's ID is the lowest job
+// ID in it, so a job that has no copies is its own cluster.
+func Cluster(jobs []database.Job) map[uint]uint {
+	keys := make([]jobKey, len(jobs))
+	for i := range jobs {
+		keys[i] = newJobKey(&jobs[i])
+	}
+
+	parent := make([]int, len(jobs))
+	for i := range parent {
+		parent[i] = i
+	}
+	var find func(int) int
+	find = func(i int) int {
+		if parent[i] != i {
+			parent[i] = find(parent[i])
+		}
+		return parent[i]
+	}
+
+	for i := range keys {
+		for j := i + 1; j < len(keys); j++ {
+			if sameRole(&keys[i], &keys[j]) {
+				parent[find(j)] = find(i)
+			}
+		}
+	}
+
+	lowest := make(map[int]uint)
+	for i, job := range jobs {
+		root := find(i)
+		if id, ok := lowest[root]; !ok || job.ID < id {
+			lowest[root] = job.ID
+		}
+	}
+	clusters := make(map[uint]uint, len(jobs))
+	for i, job := range jobs {
+		clusters[job.ID] = lowest[find(i)]
+	}
+	return clusters
+}
+
+// jobKey holds the normalised fields jobs are compared on.
+type jobKey struct {
+	title    map[string]bool
+	company  string
+	location map[string]bool
+	desc     map[string]bool // word 3-shingles
+}
+
+func newJobKey(job *database.Job) jobKey {
+	return jobKey{
+		title:    tokenSet(normaliseTitle(job.Title)),
+		company:  normaliseCompany(job.Company),
+		location: tokenSet(normaliseLocation(job.Location)),
+		desc:     shingles(words(job.Description), 3),
+	}
+}
+
+// sameRole decides whether two jobs are copies of one role. Titles must
+// nearly match and locations must not conflict. Then either the employer is
+// the same and the descriptions (when both are known) don't contradict that,
+// or the descriptions are largely the same text, as with agency re-posts.
+func sameRole(a, b *jobKey) bool {
+	if jaccard(a.title, b.title) < minTitleSimilarity {
+		return false
+	}
+	if len(a.location) > 0 && len(b.location) > 0 && !overlaps(a.location, b.location) {
+		return false
+	}
+
+	descKnown := len(a.desc) > 0 && len(b.desc) > 0
+	descSim := 0.0
+	if descKnown {
+		descSim = jaccard(a.desc, b.desc)
+	}
+
+	if a.company != "" && a.company == b.company {
+		return !descKnown || descSim >= minSameCompanyDescription
+	}
+	return descKnown && descSim >= minDescriptionSimilarity
+}
+
+// titleAbbreviations expands common shorthand in job titles.
+var titleAbbreviations = map[string]string{
+	"sr": "senior", "snr": "senior", "jr": "junior", "jnr": "junior",
+	"dev": "developer", "eng": "engineer", "mgr": "manager", "golang": "go",
+}
+
+// titleNoise are words that don't distinguish one role from another.
+var titleNoise = map[string]bool{
+	"a": true, "an": true, "the": true, "and": true, "of": true, "for": true,
+	"to": true, "in": true, "at": true, "with": true, "urgent": true,
+	"immediate": true, "start": true, "role": true, "position": true,
+	"opportunity": true, "job": true, "new": true,
+}
+
+func normaliseTitle(title string) []string {
+	var out []string
+	for _, w := range words(title) {
+		if full, ok := titleAbbreviations[w]; ok {
+			w = full
+		}
+		if !titleNoise[w] {
+			out = append(out, w)
+		}
+	}
+	return out
+}
+
+// companySuffixes are dropped from company names before comparing them.
+var companySuffixes = map[string]bool{
+	"pty": true, "ltd": true, "limited": true, "inc": true, "llc": true,
+	"group": true, "australia": true, "au": true, "co": true, "corporation": true,
+}
+
+func normaliseCompany(company string) string {
+	var out []string
+	for _, w := range words(company) {
+		if !companySuffixes[w] {
+			out = append(out, w)
+		}
+	}
+	return strings.Join(out, " ")
+}
+
+// locationNoise are state, country and work-mode words that two listings of
+// the same role often disagree on.
+var locationNoise = map[string]bool{
+	"vic": true, "victoria": true, "nsw": true, "new": true, "south": true,
+	"wales": true, "qld": true, "queensland": true, "wa": true, "western": true,
+	"sa": true, "tas": true, "tasmania": true, "act": true, "nt": true,
+	"australia": true, "au": true, "hybrid": true, "remote": true, "work": true,
+	"in": true, "cbd": true, "region": true, "area": true, "greater": true,
+}
+
+func normaliseLocation(location string) []string {
+	var out []string
+	for _, w := range words(location) {
+		if locationNoise[w] || isDigits(w) {
+			continue
+		}
+		out = append(out, w)
+	}
+	return out
+}
+
+// words lower-cases text and splits it into alphanumeric words.
+func words(text string) []string {
+	return strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '+' && r != '#'
+	})
+}
+
+func isDigits(s string) bool {
+	for _, r := range s {
+		if !unicode.IsDigit(r) {
+			return false
+		}
+	}
+	return s != ""
+}
+
+func tokenSet(tokens []string) map[string]bool {
+	set := make(map[string]bool, len(tokens))
+	for _, t := range tokens {
+		set[t] = true
+	}
+	return set
+}
+
+// shingles returns the set of n-word runs in tokens.
+func shingles(tokens []string, n int) map[string]bool {
+	set := make(map[string]bool)
+	for i := 0; i+n <= len(tokens); i++ {
+		set[strings.Join(tokens[i:i+n], " ")] = true
+	}
+	return set
+}
+
+// jaccard returns |a ∩ b| / |a ∪ b|, or 0 when both sets are empty.
+func jaccard(a, b map[string]bool) float64 {
+	if len(a) == 0 && len(b) == 0 {
+		return 0
+	}
+	if len(a) > len(b) {
+		a, b = b, a
+	}
+	shared := 0
+	for k := range a {
+		if b[k] {
+			shared++
+		}
+	}
+	return float64(shared) / float64(len(a)+len(b)-shared)
+}
+
+func overlaps(a, b map[string]bool) bool {
+	for k := range a {
+		if b[k] {
+			return true
+		}
+	}
+	return false
+}
+
+// Representative picks the job of a cluster that is best to analyse: the
+// one with the longest description, then the earliest.
+func Representative(jobs []database.Job) int {
+	best := 0
+	for i := range jobs {
+		if len(jobs[i].Description) > len(jobs[best].Description) ||
+			(len(jobs[i].Description) == len(jobs[best].Description) && jobs[i].ID < jobs[best].ID) {
+			best = i
+		}
+	}
+	return best
+}
+
+// Group splits jobs by cluster, in order of each cluster's first job. Jobs
+// that were never clustered form a cluster of their own.
+func Group(jobs []database.Job) [][]database.Job {
+	index := make(map[uint]int)
+	var groups [][]database.Job
+	for _, job := range jobs {
+		cid := job.ClusterID
+		if cid == 0 {
+			cid = job.ID
+		}
+		i, ok := index[cid]
+		if !ok {
+			i = len(groups)
+			index[cid] = i
+			groups = append(groups, nil)
+		}
+		groups[i] = append(groups[i], job)
+	}
+	return groups
+}
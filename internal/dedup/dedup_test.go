@@ -0,0 +1,112 @@
+package dedup
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/guidebee/jobseeker/internal/database"
+)
+
+const agencyAd = `Our client, a leading Melbourne fintech, is looking for a Senior Go Engineer
+to join their payments platform team. You will design and build high throughput
+services in Go, run them on Kubernetes and work closely with product and data teams.
+5+ years of backend development, strong Go and PostgreSQL, experience with Kafka.`
+
+func TestCluster(t *testing.T) {
+	jobs := []database.Job{
+		{ID: 1, Source: "seek", Title: "Senior Go Engineer", Company: "Acme Payments Pty Ltd", Location: "Melbourne VIC 3000"},
+		{ID: 2, Source: "linkedin", Title: "Sr. Go Engineer", Company: "Acme Payments", Location: "Melbourne, Victoria, Australia"},
+		{ID: 3, Source: "seek", Title: "Senior Go Engineer", Company: "Hays", Location: "Melbourne VIC", Description: agencyAd},
+		{ID: 4, Source: "indeed", Title: "Senior Golang Engineer - Urgent", Company: "Talent International", Location: "Melbourne", Description: agencyAd + " Apply now."},
+		{ID: 5, Source: "seek", Title: "Senior Go Engineer", Company: "Hudson", Location: "Melbourne VIC", Description: "Government department seeks an engineer to maintain case management systems written in Go and Java."},
+		{ID: 6, Source: "seek", Title: "Senior Go Engineer", Company: "Acme Payments", Location: "Sydney NSW"},
+		{ID: 7, Source: "linkedin", Title: "Data Analyst", Company: "Acme Payments", Location: "Melbourne VIC"},
+	}
+
+	got := Cluster(jobs)
+	want := map[uint]uint{
+		1: 1, 2: 1, // same employer on two boards
+		3: 3, 4: 3, // agencies re-posting the same ad text
+		5: 5, // same title from another agency, different role
+		6: 6, // same employer, other city
+		7: 7,
+	}
+
+	for id, cid := range want {
+		if got[id] != cid {
+			t.Errorf("job %d in cluster %d, want %d", id, got[id], cid)
+		}
+	}
+}
+
+func TestClusterAgencyRepost(t *testing.T) {
+	jobs := []database.Job{
+		{ID: 10, Title: "Senior Go Engineer", Company: "Hays", Location: "Melbourne VIC", Description: agencyAd},
+		{ID: 11, Title: "Senior Go Engineer (Contract)", Company: "Talent International", Location: "Docklands, Melbourne VIC", Description: agencyAd + " Apply now."},
+		{ID: 12, Title: "Senior Go Engineer", Company: "Randstad", Location: "Melbourne"},
+	}
+	got := Cluster(jobs)
+	if got[11] != 10 {
+		t.Errorf("agency re-post with the same text not clustered: %v", got)
+	}
+	if got[12] != 12 {
+		t.Errorf("ad without a description joined a cluster from another advertiser: %v", got)
+	}
+}
+
+func TestClusterUserJobs(t *testing.T) {
+	if err := database.InitDB(filepath.Join(t.TempDir(), "dedup.db")); err != nil {
+		t.Fatalf("InitDB failed: %v", err)
+	}
+
+	closed := time.Now()
+	jobs := []database.Job{
+		{UserID: 1, ExternalID: "seek-1", Source: "seek", Title: "Platform Engineer", Company: "Koala Analytics", Location: "Melbourne VIC"},
+		{UserID: 1, ExternalID: "linkedin-2", Source: "linkedin", Title: "Platform Engineer", Company: "Koala Analytics Pty Ltd", Location: "Melbourne, Victoria, Australia"},
+		{UserID: 1, ExternalID: "indeed-3", Source: "indeed", Title: "Site Reliability Engineer", Company: "Tidepool", Location: "Sydney NSW"},
+		{UserID: 2, ExternalID: "seek-1", Source: "seek", Title: "Platform Engineer", Company: "Koala Analytics", Location: "Melbourne VIC"},
+		{UserID: 1, ExternalID: "indeed-5", Source: "indeed", Title: "Platform Engineer", Company: "Koala Analytics", Location: "Melbourne VIC", ExpiredAt: &closed},
+	}
+	if err := database.GetDB().Create(&jobs).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	stats, err := ClusterUserJobs(1)
+	if err != nil {
+		t.Fatalf("ClusterUserJobs failed: %v", err)
+	}
+	if stats.Jobs != 3 || stats.Clusters != 1 || stats.Duplicates != 1 || stats.Updated != 3 {
+		t.Errorf("stats = %+v", stats)
+	}
+
+	var stored []database.Job
+	database.GetDB().Where("user_id = ?", 1).Order("id").Find(&stored)
+	if stored[0].ClusterID != stored[0].ID || stored[1].ClusterID != stored[0].ID || stored[2].ClusterID != stored[2].ID {
+		t.Errorf("unexpected clusters: %d %d %d", stored[0].ClusterID, stored[1].ClusterID, stored[2].ClusterID)
+	}
+	// Closed listings are left out of the pass
+	if stored[3].ClusterID != 0 {
+		t.Errorf("closed listing was clustered into %d", stored[3].ClusterID)
+	}
+
+	if stats, _ := ClusterUserJobs(1); stats.Updated != 0 {
+		t.Errorf("a second pass changed %d jobs", stats.Updated)
+	}
+
+	groups := Group(stored[:3])
+	if len(groups) != 2 || len(groups[0]) != 2 {
+		t.Errorf("Group = %v", groups)
+	}
+}
+
+func TestRepresentative(t *testing.T) {
+	jobs := []database.Job{
+		{ID: 3, Description: "short"},
+		{ID: 1, Description: "a much longer description"},
+		{ID: 2, Description: "a much longer description"},
+	}
+	if got := Representative(jobs); got != 1 {
+		t.Errorf("Representative = %d, want index 1", got)
+	}
+}
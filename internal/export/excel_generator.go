@@ -3,6 +3,7 @@ package export
 import (
 	"encoding/json"
 	"fmt"
+	"math"
 	"strings"
 
 	"github.com/guidebee/jobseeker/internal/database"
@@ -53,7 +54,7 @@ func createJobsSummarySheet(f *excelize.File, jobs []database.Job) error {
 	f.SetActiveSheet(index)
 
 	// Define headers
-	headers := []string{"ID", "Title", "Company", "Location", "Salary", "Type", "Source", "Score", "Status", "Pros", "Cons", "Date Found", "URL"}
+	headers := []string{"ID", "Title", "Company", "Location", "Salary", "Type", "Source", "Score", "Status", "Pros", "Cons", "Date Found", "URL", "Annual Pay (est.)", "Listed", "Applicants"}
 
 	// Create header style
 	headerStyle, _ := f.NewStyle(&excelize.Style{
@@ -135,6 +136,19 @@ func createJobsSummarySheet(f *excelize.File, jobs []database.Job) error {
 		f.SetCellHyperLink(sheetName, fmt.Sprintf("M%d", row), job.URL, "External")
 		f.SetCellValue(sheetName, fmt.Sprintf("M%d", row), "View Job")
 
+		// Annualised pay, for sorting and filtering contract and permanent roles together
+		if job.SalaryAnnual > 0 {
+			f.SetCellValue(sheetName, fmt.Sprintf("N%d", row), math.Round(job.SalaryAnnual))
+		}
+
+		// When the board listed the job and how many have applied, where known
+		if job.ListedAt != nil {
+			f.SetCellValue(sheetName, fmt.Sprintf("O%d", row), job.ListedAt.Local().Format("02/01/2006"))
+		}
+		if job.Applicants != nil {
+			f.SetCellValue(sheetName, fmt.Sprintf("P%d", row), *job.Applicants)
+		}
+
 		// Color code match score
 		scoreCell := fmt.Sprintf("H%d", row)
 		if job.MatchScore < 50 {
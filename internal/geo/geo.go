@@ -0,0 +1,163 @@
+// Package geo normalises Australian job locations ("Docklands, Melbourne
+// VIC", "Greater Sydney Area") to a place with coordinates, using a small
+// gazetteer of cities and business suburbs embedded in the binary.
+package geo
+
+import (
+	_ "embed"
+	"encoding/csv"
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// Place is a city or suburb from the gazetteer.
+type Place struct {
+	Name  string
+	State string // e.g. "VIC"
+	City  string // metro area, e.g. "Melbourne" for Docklands; Name for a city
+	Lat   float64
+	Lon   float64
+}
+
+//go:embed gazetteer.csv
+var gazetteerCSV string
+
+// gazetteer maps lower-case place names to their places, most populous first.
+var gazetteer = loadGazetteer()
+
+// maxNameWords is the most words in any gazetteer name.
+var maxNameWords int
+
+func loadGazetteer() map[string][]Place {
+	r := csv.NewReader(strings.NewReader(gazetteerCSV))
+	r.Comment = '#'
+	records, err := r.ReadAll()
+	if err != nil {
+		panic(fmt.Sprintf("geo: bad gazetteer: %v", err))
+	}
+
+	places := make(map[string][]Place, len(records))
+	for _, rec := range records {
+		lat, err1 := strconv.ParseFloat(rec[3], 64)
+		lon, err2 := strconv.ParseFloat(rec[4], 64)
+		if err1 != nil || err2 != nil {
+			panic(fmt.Sprintf("geo: bad coordinates for %s", rec[0]))
+		}
+		p := Place{Name: rec[0], State: rec[1], City: rec[2], Lat: lat, Lon: lon}
+		if p.City == "" {
+			p.City = p.Name
+		}
+		key := strings.Join(words(p.Name), " ")
+		places[key] = append(places[key], p)
+		maxNameWords = max(maxNameWords, len(words(p.Name)))
+	}
+	return places
+}
+
+// states maps state names and abbreviations to the abbreviation.
+var states = map[string]string{
+	"vic": "VIC", "victoria": "VIC",
+	"nsw": "NSW", "new south wales": "NSW",
+	"qld": "QLD", "queensland": "QLD",
+	"wa": "WA", "western australia": "WA",
+	"sa": "SA", "south australia": "SA",
+	"tas": "TAS", "tasmania": "TAS",
+	"act": "ACT", "australian capital territory": "ACT",
+	"nt": "NT", "northern territory": "NT",
+}
+
+// noise are words in location strings that are not part of a place name.
+var noise = map[string]bool{
+	"cbd": true, "city": true, "centre": true, "center": true, "greater": true,
+	"area": true, "region": true, "metro": true, "metropolitan": true,
+	"inner": true, "suburbs": true, "australia": true, "au": true,
+	"hybrid": true, "remote": true, "onsite": true, "on": true, "site": true,
+}
+
+var postcodeRe = regexp.MustCompile(`\b\d{4}\b`)
+
+// Lookup finds the place a location string names. The most specific place
+// wins, so "Docklands, Melbourne VIC" is Docklands; a state in the string
+// picks between places of the same name. It reports false for strings that
+// only name a state or country, such as "VIC" or "Australia".
+func Lookup(location string) (Place, bool) {
+	text := strings.ToLower(postcodeRe.ReplaceAllString(location, " "))
+
+	// Look for a state first so it can settle names like Kingston or Burwood.
+	// States come last ("Victoria Park WA"), so search from the end.
+	state := ""
+	ws := words(text)
+	for i := len(ws) - 1; i >= 0 && state == ""; i-- {
+		for n := min(3, len(ws)-i); n > 0; n-- {
+			if s, ok := states[strings.Join(ws[i:i+n], " ")]; ok {
+				state = s
+				break
+			}
+		}
+	}
+
+	for _, segment := range strings.Split(text, ",") {
+		ws := words(segment)
+		for n := min(maxNameWords, len(ws)); n > 0; n-- {
+			for i := 0; i+n <= len(ws); i++ {
+				if n == 1 && noise[ws[i]] {
+					continue
+				}
+				if p, ok := pick(gazetteer[strings.Join(ws[i:i+n], " ")], state); ok {
+					return p, true
+				}
+			}
+		}
+	}
+	return Place{}, false
+}
+
+// pick returns the place in state, or the first (most populous) one when
+// the state is not known. A name only known in other states is no match.
+func pick(places []Place, state string) (Place, bool) {
+	if len(places) == 0 {
+		return Place{}, false
+	}
+	if state == "" {
+		return places[0], true
+	}
+	for _, p := range places {
+		if p.State == state {
+			return p, true
+		}
+	}
+	return Place{}, false
+}
+
+func words(text string) []string {
+	return strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+// earthRadiusKm is the mean radius of the Earth.
+const earthRadiusKm = 6371.0
+
+// DistanceKm returns the great-circle distance between two places.
+func DistanceKm(a, b Place) float64 {
+	lat1, lat2 := a.Lat*math.Pi/180, b.Lat*math.Pi/180
+	dLat := lat2 - lat1
+	dLon := (b.Lon - a.Lon) * math.Pi / 180
+	h := math.Sin(dLat/2)*math.Sin(dLat/2) + math.Cos(lat1)*math.Cos(lat2)*math.Sin(dLon/2)*math.Sin(dLon/2)
+	return 2 * earthRadiusKm * math.Asin(math.Sqrt(h))
+}
+
+// ParseDistance parses a distance such as "25km", "25 km" or "25" (km).
+func ParseDistance(s string) (float64, error) {
+	s = strings.TrimSpace(strings.ToLower(s))
+	s = strings.TrimSpace(strings.TrimSuffix(s, "km"))
+	km, err := strconv.ParseFloat(s, 64)
+	if err != nil || km <= 0 {
+		return 0, fmt.Errorf("invalid distance %q, use e.g. 25km", s)
+	}
+	return km, nil
+}
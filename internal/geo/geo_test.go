@@ -0,0 +1,68 @@
+package geo
+
+import (
+	"math"
+	"testing"
+)
+
+func TestLookup(t *testing.T) {
+	tests := []struct {
+		location   string
+		name, city string
+		state      string
+	}{
+		{"Melbourne CBD", "Melbourne", "Melbourne", "VIC"},
+		{"Docklands, Melbourne VIC", "Docklands", "Melbourne", "VIC"},
+		{"Melbourne VIC 3000", "Melbourne", "Melbourne", "VIC"},
+		{"Greater Sydney Area", "Sydney", "Sydney", "NSW"},
+		{"North Sydney, New South Wales, Australia", "North Sydney", "Sydney", "NSW"},
+		{"Perth, Western Australia, Australia", "Perth", "Perth", "WA"},
+		{"Victoria Park WA 6100", "Victoria Park", "Perth", "WA"},
+		{"Kingston TAS", "Kingston", "Hobart", "TAS"},
+		{"Kingston ACT", "Kingston", "Canberra", "ACT"},
+		{"Richmond, Sydney NSW", "Sydney", "Sydney", "NSW"},
+		{"Hybrid remote in Parramatta NSW", "Parramatta", "Sydney", "NSW"},
+	}
+	for _, tt := range tests {
+		p, ok := Lookup(tt.location)
+		if !ok {
+			t.Errorf("Lookup(%q) found nothing", tt.location)
+			continue
+		}
+		if p.Name != tt.name || p.City != tt.city || p.State != tt.state {
+			t.Errorf("Lookup(%q) = %s (%s, %s), want %s (%s, %s)", tt.location, p.Name, p.City, p.State, tt.name, tt.city, tt.state)
+		}
+	}
+
+	for _, location := range []string{"", "AU", "Australia", "VIC", "Remote", "Anywhere"} {
+		if p, ok := Lookup(location); ok {
+			t.Errorf("Lookup(%q) = %+v, want no place", location, p)
+		}
+	}
+}
+
+func TestDistanceKm(t *testing.T) {
+	melbourne, _ := Lookup("Melbourne")
+	sydney, _ := Lookup("Sydney")
+	docklands, _ := Lookup("Docklands")
+
+	if d := DistanceKm(melbourne, sydney); math.Abs(d-714) > 10 {
+		t.Errorf("Melbourne to Sydney = %.0f km, want about 714", d)
+	}
+	if d := DistanceKm(melbourne, docklands); d > 3 {
+		t.Errorf("Melbourne to Docklands = %.1f km", d)
+	}
+}
+
+func TestParseDistance(t *testing.T) {
+	for in, want := range map[string]float64{"25km": 25, "25 km": 25, "7.5": 7.5, "100KM": 100} {
+		if got, err := ParseDistance(in); err != nil || got != want {
+			t.Errorf("ParseDistance(%q) = %v, %v, want %v", in, got, err, want)
+		}
+	}
+	for _, in := range []string{"", "far", "-5km"} {
+		if _, err := ParseDistance(in); err == nil {
+			t.Errorf("ParseDistance(%q) did not fail", in)
+		}
+	}
+}
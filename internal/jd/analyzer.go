@@ -1,32 +1,34 @@
 package jd
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"strings"
 
 	"github.com/guidebee/jobseeker/internal/profile"
 	"github.com/guidebee/jobseeker/internal/resume"
-	"github.com/guidebee/jobseeker/pkg/claude"
+	"github.com/guidebee/jobseeker/pkg/llm"
 )
 
-// JDAnalyzer handles job description analysis using Claude AI
+// JDAnalyzer handles job description analysis using the provider configured
+// for JD analysis (Claude by default)
 type JDAnalyzer struct {
-	claudeClient *claude.Client
-	profile      *profile.Profile
-	resumes      []*resume.Resume
+	provider llm.Provider
+	profile  *profile.Profile
+	resumes  []*resume.Resume
 }
 
 // NewJDAnalyzer creates a new job description analyzer
-func NewJDAnalyzer(apiKey string, prof *profile.Profile, resumes []*resume.Resume) *JDAnalyzer {
+func NewJDAnalyzer(provider llm.Provider, prof *profile.Profile, resumes []*resume.Resume) *JDAnalyzer {
 	return &JDAnalyzer{
-		claudeClient: claude.NewClient(apiKey),
-		profile:      prof,
-		resumes:      resumes,
+		provider: provider,
+		profile:  prof,
+		resumes:  resumes,
 	}
 }
 
-// AnalysisResult represents Claude's analysis of a job description
+// AnalysisResult represents the AI provider's analysis of a job description
 type AnalysisResult struct {
 	MatchScore       int      `json:"match_score"` // 0-100
 	Reasoning        string   `json:"reasoning"`
@@ -37,19 +39,23 @@ type AnalysisResult struct {
 	ResumeUsed       string   `json:"resume_used,omitempty"`
 }
 
+// PromptTemplate versions the job description analysis prompt and the
+// parsing of its replies; cached replies to an older version are not reused
+const PromptTemplate = "jd-analysis/v1"
+
 // AnalyzeJobDescription analyzes a job description against user profile and resumes
 func (a *JDAnalyzer) AnalyzeJobDescription(jd *JobDescription) (*AnalysisResult, error) {
-	// Build the prompt for Claude
+	// Build the prompt for the AI provider
 	prompt := a.buildAnalysisPrompt(jd)
 
-	// Send to Claude
-	response, err := a.claudeClient.SendMessage(prompt)
+	// Send to the JD analysis provider, unless it has answered this prompt before
+	reply, err := llm.Send(context.Background(), a.provider, llm.Request{Template: PromptTemplate, Prompt: prompt})
 	if err != nil {
-		return nil, fmt.Errorf("failed to get Claude response: %w", err)
+		return nil, fmt.Errorf("failed to get AI response: %w", err)
 	}
 
-	// Parse Claude's response
-	result, err := a.parseAnalysisResponse(response)
+	// Parse the AI provider's response
+	result, err := a.parseAnalysisResponse(reply.Text)
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse analysis: %w", err)
 	}
@@ -63,7 +69,7 @@ func (a *JDAnalyzer) AnalyzeJobDescription(jd *JobDescription) (*AnalysisResult,
 	return result, nil
 }
 
-// buildAnalysisPrompt creates a detailed prompt for Claude
+// buildAnalysisPrompt creates a detailed prompt for the AI provider
 func (a *JDAnalyzer) buildAnalysisPrompt(jd *JobDescription) string {
 	// Select best resume if available
 	var resumeContent string
@@ -122,9 +128,9 @@ Respond ONLY with valid JSON in this exact format:
 	)
 }
 
-// parseAnalysisResponse extracts the JSON from Claude's response
+// parseAnalysisResponse extracts the JSON from the AI provider's response
 func (a *JDAnalyzer) parseAnalysisResponse(response string) (*AnalysisResult, error) {
-	// Claude might wrap JSON in markdown code blocks, so clean it up
+	// Models often wrap JSON in markdown code blocks, so clean it up
 	cleaned := strings.TrimSpace(response)
 	cleaned = strings.TrimPrefix(cleaned, "```json")
 	cleaned = strings.TrimPrefix(cleaned, "```")
@@ -190,7 +196,7 @@ If additional context was provided by the user, incorporate it naturally into th
 		additionalContext,
 	)
 
-	coverLetter, err := a.claudeClient.SendMessage(prompt)
+	coverLetter, err := a.provider.SendMessage(prompt)
 	if err != nil {
 		return "", fmt.Errorf("failed to generate cover letter: %w", err)
 	}
@@ -285,7 +291,7 @@ Make the changes requested in the feedback while keeping what works well.`,
 		feedback,
 	)
 
-	refinedLetter, err := a.claudeClient.SendMessage(prompt)
+	refinedLetter, err := a.provider.SendMessage(prompt)
 	if err != nil {
 		return "", fmt.Errorf("failed to refine cover letter: %w", err)
 	}
@@ -0,0 +1,80 @@
+package profile
+
+import "strings"
+
+// Politeness limits how hard the scraper works each site, under
+// "politeness:" in config.yaml. Domains overrides Default for a host and its
+// subdomains, e.g. "indeed.com" for au.indeed.com; fields a domain leaves
+// unset come from Default, and those Default leaves unset from the built-in
+// defaults.
+type Politeness struct {
+	Default DomainPolicy            `yaml:"default"`
+	Domains map[string]DomainPolicy `yaml:"domains"`
+}
+
+// DomainPolicy is the politeness config for one domain. Zero values are
+// unset.
+type DomainPolicy struct {
+	RequestsPerMinute int   `yaml:"requests_per_minute"`
+	RespectRobots     *bool `yaml:"respect_robots"`
+	MaxRetries        int   `yaml:"max_retries"`     // retries of a 429, 403 or 503 response
+	BlockThreshold    int   `yaml:"block_threshold"` // blocked requests before the site is paused
+}
+
+// SitePolicy is the politeness policy in force for one host.
+type SitePolicy struct {
+	// RequestsPerMinute caps requests to the host; 0 means only the
+	// scraper's own delays apply
+	RequestsPerMinute int
+	// RespectRobots skips URLs the host's robots.txt disallows
+	RespectRobots bool
+	// MaxRetries is how often a 429, 403 or 503 response is retried, after
+	// waiting as long as its Retry-After header asks
+	MaxRetries int
+	// BlockThreshold is how many requests in a row may end blocked before
+	// the host is paused for the rest of the run
+	BlockThreshold int
+}
+
+// Built-in politeness defaults.
+const (
+	defaultMaxRetries     = 2
+	defaultBlockThreshold = 3
+)
+
+// For returns the policy for host. The most specific matching entry under
+// Domains wins.
+func (p Politeness) For(host string) SitePolicy {
+	policy := SitePolicy{RespectRobots: true, MaxRetries: defaultMaxRetries, BlockThreshold: defaultBlockThreshold}
+	policy = p.Default.apply(policy)
+
+	host = strings.ToLower(strings.TrimSuffix(host, "."))
+	best := ""
+	for domain := range p.Domains {
+		d := strings.ToLower(strings.TrimPrefix(domain, "."))
+		if (host == d || strings.HasSuffix(host, "."+d)) && len(d) > len(best) {
+			best = domain
+		}
+	}
+	if best != "" {
+		policy = p.Domains[best].apply(policy)
+	}
+	return policy
+}
+
+// apply overrides policy with the fields d sets.
+func (d DomainPolicy) apply(policy SitePolicy) SitePolicy {
+	if d.RequestsPerMinute > 0 {
+		policy.RequestsPerMinute = d.RequestsPerMinute
+	}
+	if d.RespectRobots != nil {
+		policy.RespectRobots = *d.RespectRobots
+	}
+	if d.MaxRetries > 0 {
+		policy.MaxRetries = d.MaxRetries
+	}
+	if d.BlockThreshold > 0 {
+		policy.BlockThreshold = d.BlockThreshold
+	}
+	return policy
+}
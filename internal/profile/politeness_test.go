@@ -0,0 +1,31 @@
+package profile
+
+import "testing"
+
+func TestPolitenessFor(t *testing.T) {
+	off := false
+	p := Politeness{
+		Default: DomainPolicy{RequestsPerMinute: 30, BlockThreshold: 5},
+		Domains: map[string]DomainPolicy{
+			"indeed.com":    {RequestsPerMinute: 10},
+			"au.indeed.com": {MaxRetries: 4},
+			"linkedin.com":  {RespectRobots: &off},
+		},
+	}
+
+	tests := []struct {
+		host string
+		want SitePolicy
+	}{
+		{"www.seek.com.au", SitePolicy{RequestsPerMinute: 30, RespectRobots: true, MaxRetries: 2, BlockThreshold: 5}},
+		{"uk.indeed.com", SitePolicy{RequestsPerMinute: 10, RespectRobots: true, MaxRetries: 2, BlockThreshold: 5}},
+		{"au.indeed.com", SitePolicy{RequestsPerMinute: 30, RespectRobots: true, MaxRetries: 4, BlockThreshold: 5}},
+		{"www.linkedin.com", SitePolicy{RequestsPerMinute: 30, RespectRobots: false, MaxRetries: 2, BlockThreshold: 5}},
+		{"notlinkedin.com", SitePolicy{RequestsPerMinute: 30, RespectRobots: true, MaxRetries: 2, BlockThreshold: 5}},
+	}
+	for _, tt := range tests {
+		if got := p.For(tt.host); got != tt.want {
+			t.Errorf("For(%q) = %+v, want %+v", tt.host, got, tt.want)
+		}
+	}
+}
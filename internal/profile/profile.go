@@ -3,7 +3,11 @@ package profile
 import (
 	"fmt"
 	"os"
+	"strings"
 
+	"github.com/guidebee/jobseeker/internal/geo"
+	"github.com/guidebee/jobseeker/internal/salary"
+	"github.com/guidebee/jobseeker/pkg/llm"
 	"gopkg.in/yaml.v3"
 )
 
@@ -33,6 +37,10 @@ type Profile struct {
 	Preferences struct {
 		JobTypes         []string `yaml:"job_types"`
 		WorkArrangements []string `yaml:"work_arrangements"`
+
+		// MaxDistanceKm is how far from one of Locations an on-site or
+		// hybrid job may be before analyze skips it (default 50)
+		MaxDistanceKm float64 `yaml:"max_distance_km"`
 	} `yaml:"preferences"`
 
 	SalaryMin int `yaml:"salary_min"`
@@ -43,13 +51,124 @@ type Profile struct {
 		AIEngineeringDailyMin int `yaml:"ai_engineering_daily_min"`
 	} `yaml:"contract"`
 
+	// Work rights, used to rule out roles for citizens only or needing a
+	// clearance
+	Eligibility Eligibility `yaml:"eligibility"`
+
 	Locations []string `yaml:"locations"`
 	Summary   string   `yaml:"summary"`
 
-	JobBoards map[string]struct {
-		Enabled    bool     `yaml:"enabled"`
-		SearchURLs []string `yaml:"search_urls"`
-	} `yaml:"job_boards"`
+	JobBoards map[string]JobBoard `yaml:"job_boards"`
+
+	// Per-site request limits, robots.txt and block handling for scans
+	Politeness Politeness `yaml:"politeness"`
+
+	// Provider and model of each AI task (analysis, jd_analysis, keywords)
+	AI llm.Settings `yaml:"ai"`
+}
+
+// Eligibility holds the work rights under "eligibility:" in config.yaml.
+// AustralianCitizen is nil when it isn't given, so that a profile without an
+// eligibility block rules nothing out. Clearance is the highest you hold or
+// could be sponsored for: none, baseline, nv1, nv2 or pv.
+type Eligibility struct {
+	AustralianCitizen *bool  `yaml:"australian_citizen"`
+	Clearance         string `yaml:"clearance"`
+}
+
+// Known reports whether any work rights are configured.
+func (e Eligibility) Known() bool {
+	return e.AustralianCitizen != nil || strings.TrimSpace(e.Clearance) != ""
+}
+
+// JobBoard configures a single job source under "job_boards:" in config.yaml.
+// The map key must match the name the board is registered under in the scraper.
+type JobBoard struct {
+	Enabled    bool     `yaml:"enabled"`
+	SearchURLs []string `yaml:"search_urls"`
+
+	// Pagination: result pages to follow per search URL (default 1) and an
+	// optional cap on jobs collected per search URL (0 = no limit)
+	MaxPages int `yaml:"max_pages"`
+	MaxJobs  int `yaml:"max_jobs"`
+
+	// Search URLs generated from the resume (seek, linkedin, indeed): at most
+	// MaxGeneratedURLs (default 10), and with PostedWithinDays set only for
+	// jobs listed that recently
+	MaxGeneratedURLs int `yaml:"max_generated_urls"`
+	PostedWithinDays int `yaml:"posted_within_days"`
+
+	// Company board slugs for ATS boards (greenhouse, lever, ashby),
+	// e.g. "canva" for https://boards.greenhouse.io/canva
+	Companies []string `yaml:"companies"`
+
+	// Employer careers pages scraped with CSS selectors (careers board)
+	Sites []CareerSite `yaml:"sites"`
+}
+
+// CareerSite describes one employer careers page for the careers board.
+// Card selects each job on the listing page; the other selectors are
+// matched inside a card, except Description which is matched on the
+// job's own page.
+type CareerSite struct {
+	Name        string `yaml:"name"`
+	Company     string `yaml:"company"` // defaults to Name
+	URL         string `yaml:"url"`
+	Card        string `yaml:"card"`
+	Title       string `yaml:"title"`
+	Location    string `yaml:"location"`
+	Link        string `yaml:"link"` // defaults to the title's or card's own href
+	Description string `yaml:"description"`
+}
+
+// Targets returns everything a board should be searched for: its search
+// URLs, its company board slugs and the names of its careers sites.
+func (b JobBoard) Targets() []string {
+	targets := make([]string, 0, len(b.SearchURLs)+len(b.Companies)+len(b.Sites))
+	targets = append(targets, b.SearchURLs...)
+	targets = append(targets, b.Companies...)
+	for _, site := range b.Sites {
+		targets = append(targets, site.Name)
+	}
+	return targets
+}
+
+// MinimumAnnualPay returns the lowest acceptable pay for a job as a yearly
+// equivalent (see salary.Pay.Annual), so contract rates and salaries can be
+// checked against one number. Contract roles, and roles paid by the hour or
+// day, use the contract rates; others use SalaryMin. It returns 0 when no
+// minimum is configured.
+func (p *Profile) MinimumAnnualPay(jobType, period string) float64 {
+	if jobType != "contract" && period != salary.Hour && period != salary.Day {
+		return float64(p.SalaryMin)
+	}
+	if p.Contract.DailyRateMin > 0 {
+		return float64(p.Contract.DailyRateMin) * salary.WorkingDaysPerYear
+	}
+	return float64(p.Contract.HourlyRateMin) * salary.HoursPerDay * salary.WorkingDaysPerYear
+}
+
+// defaultMaxDistanceKm is used when preferences.max_distance_km is not set.
+const defaultMaxDistanceKm = 50
+
+// MaxDistanceKm returns how far from a preferred location a job may be.
+func (p *Profile) MaxDistanceKm() float64 {
+	if p.Preferences.MaxDistanceKm > 0 {
+		return p.Preferences.MaxDistanceKm
+	}
+	return defaultMaxDistanceKm
+}
+
+// PreferredPlaces looks up Locations in the gazetteer. Entries that name no
+// place, like "Remote", are left out.
+func (p *Profile) PreferredPlaces() []geo.Place {
+	var places []geo.Place
+	for _, location := range p.Locations {
+		if place, ok := geo.Lookup(location); ok {
+			places = append(places, place)
+		}
+	}
+	return places
 }
 
 var CurrentProfile *Profile
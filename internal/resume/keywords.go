@@ -1,11 +1,14 @@
 package resume
 
 import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"strings"
 
-	"github.com/guidebee/jobseeker/pkg/claude"
+	"github.com/guidebee/jobseeker/pkg/llm"
 )
 
 // KeywordExtraction represents extracted keywords from resume
@@ -18,17 +21,21 @@ type KeywordExtraction struct {
 	SearchKeywords   []string `json:"search_keywords"`    // Suggested search terms
 }
 
-// ExtractKeywords uses Claude to analyze resume and extract search keywords
-func ExtractKeywords(resume *Resume, claudeClient *claude.Client) (*KeywordExtraction, error) {
+// KeywordPromptTemplate versions the keyword extraction prompt and the
+// parsing of its replies; cached replies to an older version are not reused
+const KeywordPromptTemplate = "resume-keywords/v1"
+
+// ExtractKeywords uses an AI provider to analyze resume and extract search keywords
+func ExtractKeywords(resume *Resume, provider llm.Provider) (*KeywordExtraction, error) {
 	prompt := buildKeywordPrompt(resume.Content)
 
-	response, err := claudeClient.SendMessage(prompt)
+	reply, err := llm.Send(context.Background(), provider, llm.Request{Template: KeywordPromptTemplate, Prompt: prompt})
 	if err != nil {
 		return nil, fmt.Errorf("failed to extract keywords: %w", err)
 	}
 
 	// Parse response
-	keywords, err := parseKeywordResponse(response)
+	keywords, err := parseKeywordResponse(reply.Text)
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse keywords: %w", err)
 	}
@@ -66,6 +73,67 @@ Keep search_keywords specific, relevant, and suitable for job board searches.
 Limit to 8-10 most relevant search keywords.`, truncate(resumeContent, 3000))
 }
 
+// ExtractAllKeywords extracts keywords from every resume and merges them
+// (see MergeKeywords). It fails if any resume fails, so that keywords cached
+// from it are never missing a resume.
+func ExtractAllKeywords(resumes []*Resume, provider llm.Provider) (*KeywordExtraction, error) {
+	sets := make([]*KeywordExtraction, 0, len(resumes))
+	for _, r := range resumes {
+		keywords, err := ExtractKeywords(r, provider)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", r.Filename, err)
+		}
+		sets = append(sets, keywords)
+	}
+	return MergeKeywords(sets...), nil
+}
+
+// MergeKeywords combines keyword sets without repeats (ignoring case). Each
+// list takes the sets' first entries, then their second entries and so on,
+// so the most relevant keywords of every resume come before the rest.
+func MergeKeywords(sets ...*KeywordExtraction) *KeywordExtraction {
+	pick := func(list func(*KeywordExtraction) []string) []string {
+		var merged []string
+		seen := make(map[string]bool)
+		for i := 0; ; i++ {
+			more := false
+			for _, set := range sets {
+				if set == nil || i >= len(list(set)) {
+					continue
+				}
+				more = true
+				item := strings.TrimSpace(list(set)[i])
+				if key := strings.ToLower(item); item != "" && !seen[key] {
+					seen[key] = true
+					merged = append(merged, item)
+				}
+			}
+			if !more {
+				return merged
+			}
+		}
+	}
+	return &KeywordExtraction{
+		PrimarySkills:   pick(func(k *KeywordExtraction) []string { return k.PrimarySkills }),
+		SecondarySkills: pick(func(k *KeywordExtraction) []string { return k.SecondarySkills }),
+		Roles:           pick(func(k *KeywordExtraction) []string { return k.Roles }),
+		Industries:      pick(func(k *KeywordExtraction) []string { return k.Industries }),
+		Certifications:  pick(func(k *KeywordExtraction) []string { return k.Certifications }),
+		SearchKeywords:  pick(func(k *KeywordExtraction) []string { return k.SearchKeywords }),
+	}
+}
+
+// ResumesHash fingerprints the resumes' file names and text, so cached
+// keywords can be re-extracted only when a resume is added, removed or
+// edited. Re-saving a file without changing its text keeps the hash.
+func ResumesHash(resumes []*Resume) string {
+	h := sha256.New()
+	for _, r := range resumes {
+		fmt.Fprintf(h, "%s\x00%d\x00%s\x00", r.Filename, len(r.Content), r.Content)
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
 // parseKeywordResponse extracts JSON from Claude's response
 func parseKeywordResponse(response string) (*KeywordExtraction, error) {
 	// Clean up response
@@ -84,58 +152,6 @@ func parseKeywordResponse(response string) (*KeywordExtraction, error) {
 	return &keywords, nil
 }
 
-// GenerateSearchURLs creates SEEK search URLs from keywords
-func GenerateSearchURLs(keywords *KeywordExtraction, location string) []string {
-	var urls []string
-
-	// Generate URLs from search keywords
-	for _, keyword := range keywords.SearchKeywords {
-		// URL encode the keyword
-		encodedKeyword := strings.ReplaceAll(keyword, " ", "+")
-		url := fmt.Sprintf("https://www.seek.com.au/jobs?keywords=%s&location=%s",
-			encodedKeyword, location)
-		urls = append(urls, url)
-	}
-
-	// Add role-based searches
-	for _, role := range keywords.Roles {
-		if len(urls) >= 10 { // Limit total URLs
-			break
-		}
-		encodedRole := strings.ReplaceAll(role, " ", "+")
-		url := fmt.Sprintf("https://www.seek.com.au/jobs?keywords=%s&location=%s",
-			encodedRole, location)
-		urls = append(urls, url)
-	}
-
-	return urls
-}
-
-// MergeSearchURLs combines dynamic (from resume) and static (from config) URLs
-// Removes duplicates
-func MergeSearchURLs(dynamicURLs []string, staticURLs []string) []string {
-	urlMap := make(map[string]bool)
-	var merged []string
-
-	// Add static URLs first (priority)
-	for _, url := range staticURLs {
-		if url != "" && !urlMap[url] {
-			urlMap[url] = true
-			merged = append(merged, url)
-		}
-	}
-
-	// Add dynamic URLs
-	for _, url := range dynamicURLs {
-		if url != "" && !urlMap[url] {
-			urlMap[url] = true
-			merged = append(merged, url)
-		}
-	}
-
-	return merged
-}
-
 // truncate limits string length
 func truncate(s string, maxLen int) string {
 	if len(s) <= maxLen {
@@ -0,0 +1,42 @@
+package resume
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestMergeKeywords(t *testing.T) {
+	backend := &KeywordExtraction{
+		Roles:          []string{"Backend Engineer", "Tech Lead"},
+		SearchKeywords: []string{"golang developer", "backend engineer", "kafka engineer"},
+	}
+	contract := &KeywordExtraction{
+		Roles:          []string{"Contract Developer", "tech lead"},
+		SearchKeywords: []string{"Golang Developer", "contract go developer"},
+	}
+	got := MergeKeywords(backend, contract, nil)
+
+	if want := []string{"golang developer", "backend engineer", "contract go developer", "kafka engineer"}; !slices.Equal(got.SearchKeywords, want) {
+		t.Errorf("SearchKeywords = %v, want %v", got.SearchKeywords, want)
+	}
+	if want := []string{"Backend Engineer", "Contract Developer", "Tech Lead"}; !slices.Equal(got.Roles, want) {
+		t.Errorf("Roles = %v, want %v", got.Roles, want)
+	}
+}
+
+func TestResumesHash(t *testing.T) {
+	resumes := []*Resume{{Filename: "a.docx", Content: "Go"}, {Filename: "b.docx", Content: "Python"}}
+	hash := ResumesHash(resumes)
+
+	same := []*Resume{{Filename: "a.docx", Content: "Go", FilePath: "/elsewhere/a.docx"}, {Filename: "b.docx", Content: "Python"}}
+	if ResumesHash(same) != hash {
+		t.Error("hash depends on more than names and text")
+	}
+	edited := []*Resume{{Filename: "a.docx", Content: "Go, Kafka"}, {Filename: "b.docx", Content: "Python"}}
+	if ResumesHash(edited) == hash {
+		t.Error("hash unchanged after a resume was edited")
+	}
+	if ResumesHash(resumes[:1]) == hash {
+		t.Error("hash unchanged after a resume was removed")
+	}
+}
@@ -0,0 +1,316 @@
+package resume
+
+import (
+	"fmt"
+	"net/url"
+	"slices"
+	"sort"
+	"strings"
+)
+
+// DefaultMaxSearchURLs is how many search URLs are generated per board when
+// SearchOptions.MaxURLs is not set.
+const DefaultMaxSearchURLs = 10
+
+// linkedInAustralia is LinkedIn's geoId for Australia, used for remote
+// searches that aren't tied to a city.
+const linkedInAustralia = "101452733"
+
+// SearchOptions narrows the search URLs generated from resume keywords.
+type SearchOptions struct {
+	// Locations to search, e.g. "Melbourne, VIC". "Remote" asks for remote
+	// searches rather than naming a place.
+	Locations []string
+	// Arrangements as in preferences.work_arrangements ("Remote", "On-site",
+	// "Hybrid"); none means any.
+	Arrangements []string
+	// JobTypes as in preferences.job_types ("Full-time", "Contract"); none
+	// means any.
+	JobTypes []string
+	// PostedWithinDays limits searches to recent listings; 0 means any age.
+	PostedWithinDays int
+	// MaxURLs caps the URLs generated for one board; 0 means
+	// DefaultMaxSearchURLs.
+	MaxURLs int
+}
+
+// Work arrangements a search can ask for.
+const (
+	arrangementAny    = ""
+	arrangementRemote = "remote"
+	arrangementHybrid = "hybrid"
+	arrangementOnsite = "onsite"
+)
+
+// search is one location and arrangement to search a term in. An empty
+// location searches the whole country.
+type search struct {
+	location    string
+	arrangement string
+}
+
+// searchURLBuilders build one board's search URL for a term.
+var searchURLBuilders = map[string]func(term string, s search, opts SearchOptions) string{
+	"seek":     seekSearchURL,
+	"linkedin": linkedInSearchURL,
+	"indeed":   indeedSearchURL,
+}
+
+// HasSearchURLBuilder reports whether GenerateSearchURLs can build search
+// URLs for the named board.
+func HasSearchURLBuilder(board string) bool {
+	_, ok := searchURLBuilders[board]
+	return ok
+}
+
+// GenerateSearchURLs builds a board's search URLs from resume keywords: each
+// search keyword, then each role, in every configured location and work
+// arrangement. URLs are generated in that order and de-duplicated, so the
+// same keywords and options always give the same URLs, and generation stops
+// at the board's cap. It returns nil for boards without a URL builder.
+func GenerateSearchURLs(board string, keywords *KeywordExtraction, opts SearchOptions) []string {
+	build, ok := searchURLBuilders[board]
+	if !ok || keywords == nil {
+		return nil
+	}
+	limit := opts.MaxURLs
+	if limit <= 0 {
+		limit = DefaultMaxSearchURLs
+	}
+
+	var urls []string
+	seen := make(map[string]bool)
+	for _, term := range searchTerms(keywords) {
+		for _, s := range searches(opts) {
+			u := build(term, s, opts)
+			if key := canonicalURL(u); !seen[key] {
+				seen[key] = true
+				urls = append(urls, u)
+			}
+			if len(urls) >= limit {
+				return urls
+			}
+		}
+	}
+	return urls
+}
+
+// searchTerms returns the search keywords followed by the roles, trimmed and
+// without repeats (ignoring case).
+func searchTerms(keywords *KeywordExtraction) []string {
+	var terms []string
+	seen := make(map[string]bool)
+	for _, term := range append(slices.Clone(keywords.SearchKeywords), keywords.Roles...) {
+		term = strings.Join(strings.Fields(term), " ")
+		if key := strings.ToLower(term); term != "" && !seen[key] {
+			seen[key] = true
+			terms = append(terms, term)
+		}
+	}
+	return terms
+}
+
+// searches pairs every location with every arrangement. Remote work isn't
+// tied to a place, so remote is searched once, country-wide.
+func searches(opts SearchOptions) []search {
+	var locations []string
+	arrangements := normaliseArrangements(opts.Arrangements)
+	for _, location := range opts.Locations {
+		location = strings.TrimSpace(location)
+		switch {
+		case location == "":
+		case normaliseArrangement(location) == arrangementRemote:
+			// "Remote" under locations asks for remote work
+			if !slices.Contains(arrangements, arrangementRemote) {
+				arrangements = append(arrangements, arrangementRemote)
+			}
+		case !slices.Contains(locations, location):
+			locations = append(locations, location)
+		}
+	}
+	if len(arrangements) == 0 {
+		arrangements = []string{arrangementAny}
+	}
+	if len(locations) == 0 {
+		locations = []string{""}
+	}
+
+	var out []search
+	for _, arrangement := range arrangements {
+		if arrangement == arrangementRemote {
+			out = append(out, search{arrangement: arrangementRemote})
+			continue
+		}
+		for _, location := range locations {
+			out = append(out, search{location: location, arrangement: arrangement})
+		}
+	}
+	return out
+}
+
+// normaliseArrangements maps configured arrangements to remote, hybrid and
+// onsite, in their configured order, dropping any it doesn't know.
+func normaliseArrangements(arrangements []string) []string {
+	var out []string
+	for _, a := range arrangements {
+		if a = normaliseArrangement(a); a != "" && !slices.Contains(out, a) {
+			out = append(out, a)
+		}
+	}
+	return out
+}
+
+func normaliseArrangement(arrangement string) string {
+	switch strings.NewReplacer("-", "", " ", "").Replace(strings.ToLower(arrangement)) {
+	case "remote", "wfh", "workfromhome":
+		return arrangementRemote
+	case "hybrid":
+		return arrangementHybrid
+	case "onsite", "inoffice", "office":
+		return arrangementOnsite
+	}
+	return ""
+}
+
+// normaliseJobType maps a configured job type to one of contract, permanent,
+// full-time, part-time, casual or temporary, or "" if unknown.
+func normaliseJobType(jobType string) string {
+	switch strings.NewReplacer("-", "", " ", "").Replace(strings.ToLower(jobType)) {
+	case "contract", "contractor":
+		return "contract"
+	case "permanent":
+		return "permanent"
+	case "fulltime":
+		return "full-time"
+	case "parttime":
+		return "part-time"
+	case "casual":
+		return "casual"
+	case "temporary", "temp":
+		return "temporary"
+	}
+	return ""
+}
+
+// seekSearchURL builds a SEEK search. SEEK's URLs have no work arrangement
+// filter, so remote searches cover all of Australia.
+func seekSearchURL(term string, s search, _ SearchOptions) string {
+	q := url.Values{}
+	q.Set("keywords", term)
+	if s.location != "" {
+		q.Set("where", s.location)
+	}
+	return "https://www.seek.com.au/jobs?" + q.Encode()
+}
+
+// LinkedIn's f_WT work arrangement and f_JT job type codes.
+var (
+	linkedInArrangements = map[string]string{arrangementOnsite: "1", arrangementRemote: "2", arrangementHybrid: "3"}
+	linkedInJobTypes     = map[string]string{
+		"full-time": "F", "permanent": "F", "part-time": "P", "contract": "C", "temporary": "T", "casual": "T",
+	}
+)
+
+// linkedInSearchURL builds a LinkedIn search with f_WT (work arrangement),
+// f_JT (job types) and f_TPR (posted within, in seconds) filters.
+func linkedInSearchURL(term string, s search, opts SearchOptions) string {
+	q := url.Values{}
+	q.Set("keywords", term)
+	if s.location != "" {
+		q.Set("location", s.location)
+	} else {
+		q.Set("geoId", linkedInAustralia)
+	}
+	if code, ok := linkedInArrangements[s.arrangement]; ok {
+		q.Set("f_WT", code)
+	}
+	if codes := jobTypeCodes(opts.JobTypes, linkedInJobTypes); len(codes) > 0 {
+		q.Set("f_JT", strings.Join(codes, ","))
+	}
+	if opts.PostedWithinDays > 0 {
+		q.Set("f_TPR", fmt.Sprintf("r%d", opts.PostedWithinDays*24*60*60))
+	}
+	return "https://www.linkedin.com/jobs/search/?" + q.Encode()
+}
+
+// Indeed's jt job type values, its fromage day ranges, and the sc filter its
+// own "Remote" checkbox adds.
+var (
+	indeedJobTypes = map[string]string{
+		"full-time": "fulltime", "permanent": "permanent", "part-time": "parttime",
+		"contract": "contract", "casual": "casual", "temporary": "temporary",
+	}
+	indeedAges   = []int{1, 3, 7, 14}
+	indeedRemote = "0kf:attr(DSQF7);"
+)
+
+// indeedSearchURL builds an Indeed search. Indeed takes a single jt job type,
+// so jt is only set when the job types all map to one; fromage is rounded up
+// to the nearest range Indeed offers. Indeed has no hybrid filter, so hybrid
+// searches are plain location searches.
+func indeedSearchURL(term string, s search, opts SearchOptions) string {
+	q := url.Values{}
+	q.Set("q", term)
+	if s.arrangement == arrangementRemote {
+		q.Set("sc", indeedRemote)
+	} else if s.location != "" {
+		q.Set("l", s.location)
+	}
+	if codes := jobTypeCodes(opts.JobTypes, indeedJobTypes); len(codes) == 1 {
+		q.Set("jt", codes[0])
+	}
+	if opts.PostedWithinDays > 0 {
+		for _, days := range indeedAges {
+			if days >= opts.PostedWithinDays {
+				q.Set("fromage", fmt.Sprint(days))
+				break
+			}
+		}
+	}
+	return "https://au.indeed.com/jobs?" + q.Encode()
+}
+
+// jobTypeCodes maps job types to a board's codes, sorted and without repeats.
+func jobTypeCodes(jobTypes []string, codes map[string]string) []string {
+	var out []string
+	for _, jobType := range jobTypes {
+		if code, ok := codes[normaliseJobType(jobType)]; ok && !slices.Contains(out, code) {
+			out = append(out, code)
+		}
+	}
+	sort.Strings(out)
+	return out
+}
+
+// canonicalURL returns the form of a search URL used to spot duplicates: the
+// host lower-cased and the query re-encoded in key order, case-insensitively,
+// so "keywords=Go%20Developer" and "keywords=go+developer" are the same
+// search. Strings that don't parse as URLs are compared as they are.
+func canonicalURL(raw string) string {
+	u, err := url.Parse(strings.TrimSpace(raw))
+	if err != nil || u.Host == "" {
+		return raw
+	}
+	q := u.Query()
+	for key, values := range q {
+		if len(values) == 1 && values[0] == "" {
+			q.Del(key)
+		}
+	}
+	return strings.ToLower(u.Scheme + "://" + u.Host + strings.TrimSuffix(u.Path, "/") + "?" + q.Encode())
+}
+
+// MergeSearchURLs combines dynamic (from resume) and static (from config)
+// URLs. Static URLs come first, and a URL that is the same search as an
+// earlier one (see canonicalURL) is dropped.
+func MergeSearchURLs(dynamicURLs []string, staticURLs []string) []string {
+	seen := make(map[string]bool)
+	var merged []string
+	for _, u := range append(slices.Clone(staticURLs), dynamicURLs...) {
+		if key := canonicalURL(u); u != "" && !seen[key] {
+			seen[key] = true
+			merged = append(merged, u)
+		}
+	}
+	return merged
+}
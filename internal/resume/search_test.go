@@ -0,0 +1,85 @@
+package resume
+
+import (
+	"slices"
+	"testing"
+)
+
+var testKeywords = &KeywordExtraction{
+	SearchKeywords: []string{"golang developer", "Golang  Developer", "platform engineer"},
+	Roles:          []string{"Platform Engineer", "Tech Lead"},
+}
+
+func TestGenerateSearchURLsLinkedIn(t *testing.T) {
+	opts := SearchOptions{
+		Locations:        []string{"Remote", "Melbourne, VIC", "Perth, WA"},
+		Arrangements:     []string{"Hybrid"},
+		JobTypes:         []string{"Full-time", "Contract"},
+		PostedWithinDays: 7,
+		MaxURLs:          4,
+	}
+	got := GenerateSearchURLs("linkedin", testKeywords, opts)
+	want := []string{
+		"https://www.linkedin.com/jobs/search/?f_JT=C%2CF&f_TPR=r604800&f_WT=3&keywords=golang+developer&location=Melbourne%2C+VIC",
+		"https://www.linkedin.com/jobs/search/?f_JT=C%2CF&f_TPR=r604800&f_WT=3&keywords=golang+developer&location=Perth%2C+WA",
+		"https://www.linkedin.com/jobs/search/?f_JT=C%2CF&f_TPR=r604800&f_WT=2&geoId=101452733&keywords=golang+developer",
+		"https://www.linkedin.com/jobs/search/?f_JT=C%2CF&f_TPR=r604800&f_WT=3&keywords=platform+engineer&location=Melbourne%2C+VIC",
+	}
+	if !slices.Equal(got, want) {
+		t.Errorf("GenerateSearchURLs =\n%v\nwant\n%v", got, want)
+	}
+	if again := GenerateSearchURLs("linkedin", testKeywords, opts); !slices.Equal(again, got) {
+		t.Error("GenerateSearchURLs is not deterministic")
+	}
+}
+
+func TestGenerateSearchURLsIndeed(t *testing.T) {
+	got := GenerateSearchURLs("indeed", testKeywords, SearchOptions{
+		Locations:        []string{"Melbourne, VIC"},
+		Arrangements:     []string{"Remote", "On-site"},
+		JobTypes:         []string{"Contract"},
+		PostedWithinDays: 5,
+	})
+	want := []string{
+		"https://au.indeed.com/jobs?fromage=7&jt=contract&q=golang+developer&sc=0kf%3Aattr%28DSQF7%29%3B",
+		"https://au.indeed.com/jobs?fromage=7&jt=contract&l=Melbourne%2C+VIC&q=golang+developer",
+		"https://au.indeed.com/jobs?fromage=7&jt=contract&q=platform+engineer&sc=0kf%3Aattr%28DSQF7%29%3B",
+		"https://au.indeed.com/jobs?fromage=7&jt=contract&l=Melbourne%2C+VIC&q=platform+engineer",
+		"https://au.indeed.com/jobs?fromage=7&jt=contract&q=Tech+Lead&sc=0kf%3Aattr%28DSQF7%29%3B",
+		"https://au.indeed.com/jobs?fromage=7&jt=contract&l=Melbourne%2C+VIC&q=Tech+Lead",
+	}
+	if !slices.Equal(got, want) {
+		t.Errorf("GenerateSearchURLs =\n%v\nwant\n%v", got, want)
+	}
+}
+
+func TestGenerateSearchURLsDefaults(t *testing.T) {
+	many := &KeywordExtraction{}
+	for _, k := range []string{"a", "b", "c", "d", "e", "f", "g", "h", "i", "j", "k", "l"} {
+		many.SearchKeywords = append(many.SearchKeywords, k+" developer")
+	}
+	if got := GenerateSearchURLs("seek", many, SearchOptions{Locations: []string{"Sydney NSW"}}); len(got) != DefaultMaxSearchURLs {
+		t.Errorf("got %d URLs, want the default cap of %d", len(got), DefaultMaxSearchURLs)
+	}
+	if got := GenerateSearchURLs("careers", many, SearchOptions{}); got != nil {
+		t.Errorf("careers has no search URLs, got %v", got)
+	}
+}
+
+func TestMergeSearchURLs(t *testing.T) {
+	static := []string{
+		"https://www.linkedin.com/jobs/search/?keywords=golang%20developer&location=Melbourne%2C%20VIC",
+		"canva",
+	}
+	dynamic := []string{
+		"https://www.linkedin.com/jobs/search/?keywords=golang+developer&location=Melbourne%2C+VIC",
+		"https://www.linkedin.com/jobs/search?location=Melbourne%2C+VIC&keywords=Golang+Developer",
+		"https://www.linkedin.com/jobs/search/?keywords=tech+lead&location=Melbourne%2C+VIC",
+		"canva",
+	}
+	got := MergeSearchURLs(dynamic, static)
+	want := append(slices.Clone(static), dynamic[2])
+	if !slices.Equal(got, want) {
+		t.Errorf("MergeSearchURLs =\n%v\nwant\n%v", got, want)
+	}
+}
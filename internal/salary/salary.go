@@ -0,0 +1,244 @@
+// Package salary parses the free-text pay of a job ad, such as
+// "$900 - $1,050 per day + super", into numbers that can be compared
+// across contract and permanent roles.
+package salary
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Pay periods.
+const (
+	Hour  = "hour"
+	Day   = "day"
+	Week  = "week"
+	Month = "month"
+	Year  = "year"
+)
+
+// Assumptions used to annualise a rate. A contractor is paid for about 220
+// days a year once leave, public holidays and gaps between contracts are
+// taken out, so a day or hour rate is compared with a salary on that basis.
+// Weekly and monthly pay are salaries, paid through leave, so they are
+// annualised over the whole year.
+const (
+	WorkingDaysPerYear = 220
+	HoursPerDay        = 7.6
+	WeeksPerYear       = 52
+	MonthsPerYear      = 12
+
+	// SuperRate is the superannuation guarantee, used to take super out of a
+	// package that includes it.
+	SuperRate = 0.12
+)
+
+// Version is stored with each parsed salary; bump it when Parse or Annual
+// change so that stored salaries are parsed again.
+const Version = 3
+
+// Pay is the structured form of an advertised salary or rate.
+type Pay struct {
+	Min      float64 // 0 for "up to" amounts
+	Max      float64 // equal to Min when a single amount is advertised
+	Currency string  // ISO code, "AUD" unless the ad says otherwise
+	Period   string  // Hour, Day, Week, Month or Year
+
+	// SuperIncluded is set when the amount is a package that includes
+	// superannuation ("incl. super", "package"), as opposed to "+ super".
+	SuperIncluded bool
+}
+
+// Annual returns the yearly equivalent of the top of the advertised range,
+// excluding super, so an hourly or daily rate can be compared with a salary.
+func (p Pay) Annual() float64 {
+	amount := p.Max
+	if amount == 0 {
+		amount = p.Min
+	}
+	switch p.Period {
+	case Hour:
+		amount *= HoursPerDay * WorkingDaysPerYear
+	case Day:
+		amount *= WorkingDaysPerYear
+	case Week:
+		amount *= WeeksPerYear
+	case Month:
+		amount *= MonthsPerYear
+	case Year:
+	default:
+		return 0
+	}
+	if p.SuperIncluded {
+		amount /= 1 + SuperRate
+	}
+	return amount
+}
+
+// periodPatterns recognise how ads spell out a pay period.
+var periodPatterns = []struct {
+	period string
+	re     *regexp.Regexp
+}{
+	{Hour, regexp.MustCompile(`(?i)(\bper|\ban|/)\s*(hour|hr|h)\b|\bp\.?\s?h\b|hourly`)},
+	{Day, regexp.MustCompile(`(?i)(\bper|\ba|/)\s*(day|d)\b|\bp\.?\s?d\b|daily`)},
+	{Week, regexp.MustCompile(`(?i)(\bper|\ba|/)\s*(week|wk|w)\b|\bp\.?\s?w\b|weekly`)},
+	{Month, regexp.MustCompile(`(?i)(\bper|\ba|/)\s*(month|mth)\b|\bpcm\b|monthly`)},
+	{Year, regexp.MustCompile(`(?i)(\bper|\ba|/)\s*(annum|year|yr)\b|\bp\.?\s?a\b|annual|yearly`)},
+}
+
+var (
+	// amountRe matches an amount with an optional currency before it and an
+	// optional "k" after it: "$1,050", "A$120K", "95.5k", "£600".
+	amountRe = regexp.MustCompile(`(?i)(aud|nzd|usd|gbp|eur|[a-z]{0,2}\$|£|€)?\s*(\d[\d,]*(?:\.\d+)?)\s*(k\b)?(\s*%)?`)
+
+	superIncludedRe = regexp.MustCompile(`(?i)(incl?\.?|including|inclusive of|plus|\+)?\s*(super(annuation)?)\s*(incl(uded|usive)?)?|\bpackage\b|\btrp\b|total remuneration`)
+
+	upToRe = regexp.MustCompile(`(?i)\bup\s*to\b`)
+
+	// rangeSepRe matches what may sit between the bounds of a range
+	rangeSepRe = regexp.MustCompile(`(?i)^\s*(-|–|—|to)\s*$`)
+)
+
+// currencies maps the symbols and codes ads use to ISO codes.
+var currencies = map[string]string{
+	"$": "AUD", "a$": "AUD", "au$": "AUD", "aud": "AUD",
+	"nz$": "NZD", "nzd": "NZD",
+	"us$": "USD", "usd": "USD",
+	"£": "GBP", "gbp": "GBP",
+	"€": "EUR", "eur": "EUR",
+}
+
+// Parse extracts the pay from an ad's salary text. It reports false when the
+// text has no amount it can make sense of, such as "Competitive" or
+// "Negotiable".
+func Parse(text string) (Pay, bool) {
+	pay := Pay{Currency: "AUD"}
+
+	var amounts []float64
+	var start, end int // where the amounts kept are in text
+	var marked bool    // any amount had a currency or "k"
+	var firstK bool    // the first amount kept had a "k"
+	for _, loc := range amountRe.FindAllStringSubmatchIndex(text, -1) {
+		m := submatches(text, loc)
+		if m[4] != "" { // a percentage, e.g. "+ 11.5% super"
+			continue
+		}
+		n, err := strconv.ParseFloat(strings.ReplaceAll(m[2], ",", ""), 64)
+		if err != nil || n == 0 {
+			continue
+		}
+		if m[3] != "" {
+			n *= 1000
+		}
+		isMarked := m[1] != "" || m[3] != ""
+		if isMarked && !marked {
+			// Bare numbers before the first real amount are usually noise
+			// like "12 month contract"
+			marked, amounts = true, amounts[:0]
+		}
+		// The upper bound of a range often drops the "$" ("$900 - 1,050")
+		// or carries the only "k" ("$130-150k")
+		rangeEnd := len(amounts) == 1 && rangeSepRe.MatchString(text[end:loc[4]])
+		if marked && !isMarked && !rangeEnd {
+			// Any other bare number after a real amount is something else,
+			// like "6 month contract" or "38 hours per week"
+			continue
+		}
+		if rangeEnd && m[3] != "" && !firstK && amounts[0] < 1000 {
+			amounts[0] *= 1000
+		}
+		if code, ok := currencies[strings.ToLower(m[1])]; ok && len(amounts) == 0 {
+			pay.Currency = code
+		}
+		if len(amounts) == 0 {
+			start, firstK = loc[0], m[3] != ""
+		}
+		end = loc[1]
+		amounts = append(amounts, n)
+	}
+	if len(amounts) == 0 {
+		return Pay{}, false
+	}
+
+	switch {
+	case len(amounts) == 1 && upToRe.MatchString(text):
+		pay.Max = amounts[0]
+	case len(amounts) == 1:
+		pay.Min, pay.Max = amounts[0], amounts[0]
+	default:
+		pay.Min, pay.Max = amounts[0], amounts[1]
+		if pay.Min > pay.Max {
+			pay.Min, pay.Max = pay.Max, pay.Min
+		}
+	}
+
+	pay.Period = period(text, start, end, pay.Max)
+	if pay.Period == "" {
+		return Pay{}, false
+	}
+	pay.SuperIncluded = superIncluded(text)
+	return pay, true
+}
+
+// submatches returns the text of each group of a FindAllStringSubmatchIndex
+// match, "" for groups that didn't match.
+func submatches(text string, loc []int) []string {
+	m := make([]string, len(loc)/2)
+	for i := range m {
+		if loc[2*i] >= 0 {
+			m[i] = text[loc[2*i]:loc[2*i+1]]
+		}
+	}
+	return m
+}
+
+// period finds the pay period of the amount at text[start:end]: the period
+// phrase nearest to it, so that "$120k p.a., 38 hours per week" is a yearly
+// salary. When the ad doesn't say, it guesses from the size of the amount,
+// and returns "" when the amount fits none.
+func period(text string, start, end int, amount float64) string {
+	best, bestDistance := "", len(text)+1
+	for _, p := range periodPatterns {
+		for _, loc := range p.re.FindAllStringIndex(text, -1) {
+			distance := loc[0] - end
+			if loc[1] <= start {
+				distance = start - loc[1]
+			}
+			if distance = max(distance, 0); distance < bestDistance {
+				best, bestDistance = p.period, distance
+			}
+		}
+	}
+	if best != "" {
+		return best
+	}
+	switch {
+	case amount >= 20000:
+		return Year
+	case amount >= 250 && amount <= 5000:
+		return Day
+	case amount >= 15 && amount < 250:
+		return Hour
+	}
+	return ""
+}
+
+// superIncluded reports whether text says the amount includes super.
+// "+ super" and "plus super" mean super is paid on top.
+func superIncluded(text string) bool {
+	for _, m := range superIncludedRe.FindAllStringSubmatch(text, -1) {
+		if m[2] == "" {
+			return true // "package", "TRP", "total remuneration"
+		}
+		prefix := strings.ToLower(m[1])
+		if prefix == "plus" || prefix == "+" {
+			continue
+		}
+		if prefix != "" || m[4] != "" {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,75 @@
+package salary
+
+import (
+	"math"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		text string
+		want Pay
+	}{
+		{"$900 - $1,050 per day + super", Pay{Min: 900, Max: 1050, Currency: "AUD", Period: Day}},
+		{"$120k - $140k p.a. + super", Pay{Min: 120000, Max: 140000, Currency: "AUD", Period: Year}},
+		{"$150,000 - $170,000 package", Pay{Min: 150000, Max: 170000, Currency: "AUD", Period: Year, SuperIncluded: true}},
+		{"$180K incl. super", Pay{Min: 180000, Max: 180000, Currency: "AUD", Period: Year, SuperIncluded: true}},
+		{"$85 - $95 p.h. + 12% super", Pay{Min: 85, Max: 95, Currency: "AUD", Period: Hour}},
+		{"Up to $1,100/day", Pay{Max: 1100, Currency: "AUD", Period: Day}},
+		{"6 month contract, $850 per day", Pay{Min: 850, Max: 850, Currency: "AUD", Period: Day}},
+		{"$850 per day, 6 month contract", Pay{Min: 850, Max: 850, Currency: "AUD", Period: Day}},
+		{"$95 - $105 p.h., 38 hours per week", Pay{Min: 95, Max: 105, Currency: "AUD", Period: Hour}},
+		{"$1,200 per day for 3 days a week, 12 month contract", Pay{Min: 1200, Max: 1200, Currency: "AUD", Period: Day}},
+		{"$120k p.a., 38 hours per week", Pay{Min: 120000, Max: 120000, Currency: "AUD", Period: Year}},
+		{"Full time, 38 hours per week. $110,000 - $125,000 per annum + super", Pay{Min: 110000, Max: 125000, Currency: "AUD", Period: Year}},
+		{"$1,100 per day, paid weekly", Pay{Min: 1100, Max: 1100, Currency: "AUD", Period: Day}},
+		{"$900 - 1,050 per day", Pay{Min: 900, Max: 1050, Currency: "AUD", Period: Day}},
+		{"$120,000 - 140,000", Pay{Min: 120000, Max: 140000, Currency: "AUD", Period: Year}},
+		{"$110,000 to 130,000 + super", Pay{Min: 110000, Max: 130000, Currency: "AUD", Period: Year}},
+		{"$130-150k", Pay{Min: 130000, Max: 150000, Currency: "AUD", Period: Year}},
+		{"$80 - 90k", Pay{Min: 80000, Max: 90000, Currency: "AUD", Period: Year}},
+		{"NZ$130,000 - 150,000 a year", Pay{Min: 130000, Max: 150000, Currency: "NZD", Period: Year}},
+		{"NZ$130,000 - NZ$150,000 a year", Pay{Min: 130000, Max: 150000, Currency: "NZD", Period: Year}},
+		{"$145,000 + Super", Pay{Min: 145000, Max: 145000, Currency: "AUD", Period: Year}},
+		{"$1000", Pay{Min: 1000, Max: 1000, Currency: "AUD", Period: Day}},
+		{"Technical Lead $150k - $160k", Pay{Min: 150000, Max: 160000, Currency: "AUD", Period: Year}},
+		{"$8,000 - $9,000 per month", Pay{Min: 8000, Max: 9000, Currency: "AUD", Period: Month}},
+	}
+	for _, tt := range tests {
+		got, ok := Parse(tt.text)
+		if !ok {
+			t.Errorf("Parse(%q) failed", tt.text)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("Parse(%q) = %+v, want %+v", tt.text, got, tt.want)
+		}
+	}
+}
+
+func TestParseUnknown(t *testing.T) {
+	for _, text := range []string{"", "Competitive", "Negotiable + bonus", "$9,000"} {
+		if pay, ok := Parse(text); ok {
+			t.Errorf("Parse(%q) = %+v, want no pay", text, pay)
+		}
+	}
+}
+
+func TestAnnual(t *testing.T) {
+	tests := []struct {
+		pay  Pay
+		want float64
+	}{
+		{Pay{Min: 900, Max: 1050, Period: Day}, 231000},
+		{Pay{Min: 100, Max: 100, Period: Hour}, 167200},
+		{Pay{Min: 150000, Max: 168000, Period: Year, SuperIncluded: true}, 150000},
+		{Pay{Max: 10000, Period: Month}, 120000},
+		{Pay{Min: 2000, Max: 2000, Period: Week}, 104000},
+		{Pay{Min: 100}, 0},
+	}
+	for _, tt := range tests {
+		if got := tt.pay.Annual(); math.Abs(got-tt.want) > 0.01 {
+			t.Errorf("%+v.Annual() = %.2f, want %.2f", tt.pay, got, tt.want)
+		}
+	}
+}
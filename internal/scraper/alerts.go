@@ -0,0 +1,275 @@
+package scraper
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"log"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net/mail"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+	"github.com/guidebee/jobseeker/internal/database"
+)
+
+// ParseAlerts reads job alert emails from an mbox file, a single .eml file or
+// a directory of .eml files, and returns one job per distinct SEEK, LinkedIn
+// or Indeed job linked from them.
+func ParseAlerts(path string) ([]*database.Job, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var messages [][]byte
+	if info.IsDir() {
+		err = filepath.WalkDir(path, func(p string, d os.DirEntry, err error) error {
+			if err != nil || d.IsDir() || !strings.EqualFold(filepath.Ext(p), ".eml") {
+				return err
+			}
+			raw, err := os.ReadFile(p)
+			if err != nil {
+				return err
+			}
+			messages = append(messages, raw)
+			return nil
+		})
+	} else {
+		var raw []byte
+		if raw, err = os.ReadFile(path); err == nil {
+			messages = splitMailbox(raw)
+		}
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+
+	var jobs []*database.Job
+	byID := make(map[string]*database.Job)
+	for _, raw := range messages {
+		links, err := alertLinks(raw)
+		if err != nil {
+			log.Printf("Skipping email: %v", err)
+			continue
+		}
+		for _, link := range links {
+			job := alertJob(link.href)
+			if job == nil {
+				continue
+			}
+			if existing, ok := byID[job.ExternalID]; ok {
+				// The same job is often linked from its title and an "Apply" button
+				if existing.Title == "" {
+					existing.Title = link.text
+				}
+				continue
+			}
+			job.Title = link.text
+			byID[job.ExternalID] = job
+			jobs = append(jobs, job)
+		}
+	}
+
+	for _, job := range jobs {
+		job.JobType = database.DetectJobType(job.Title, "", job.URL)
+	}
+	return jobs, nil
+}
+
+// splitMailbox splits an mbox file into raw messages. A file that doesn't
+// start with an mbox "From " line is treated as a single message.
+func splitMailbox(raw []byte) [][]byte {
+	if !bytes.HasPrefix(raw, []byte("From ")) {
+		return [][]byte{raw}
+	}
+
+	var messages [][]byte
+	var current bytes.Buffer
+	prevBlank := true
+	r := bufio.NewReader(bytes.NewReader(raw))
+	for {
+		line, err := r.ReadBytes('\n')
+		if len(line) > 0 {
+			switch {
+			case prevBlank && bytes.HasPrefix(line, []byte("From ")):
+				if current.Len() > 0 {
+					messages = append(messages, append([]byte(nil), current.Bytes()...))
+					current.Reset()
+				}
+			case line[0] == '>' && bytes.HasPrefix(bytes.TrimLeft(line, ">"), []byte("From ")):
+				current.Write(line[1:]) // undo mboxrd ">From " quoting
+			default:
+				current.Write(line)
+			}
+			prevBlank = len(bytes.TrimSpace(line)) == 0
+		}
+		if err != nil {
+			break
+		}
+	}
+	if current.Len() > 0 {
+		messages = append(messages, current.Bytes())
+	}
+	return messages
+}
+
+// alertLink is a link found in an alert email and the text it was shown with.
+type alertLink struct {
+	href string
+	text string
+}
+
+// alertLinkTextIgnored lists button labels that are not job titles.
+var alertLinkTextIgnored = map[string]bool{
+	"view job": true, "view": true, "apply": true, "apply now": true,
+	"easy apply": true, "see job": true, "view details": true, "more": true,
+}
+
+// plainURLRe finds bare links in text/plain alert bodies.
+var plainURLRe = regexp.MustCompile(`https?://[^\s<>"]+`)
+
+// alertLinks returns the links in every HTML part of a message, falling back
+// to the plain-text parts when the message has no HTML.
+func alertLinks(raw []byte) ([]alertLink, error) {
+	msg, err := mail.ReadMessage(bytes.NewReader(raw))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse email: %w", err)
+	}
+
+	var htmlParts, textParts []string
+	if err := walkMIME(msg.Header.Get("Content-Type"), msg.Header.Get("Content-Transfer-Encoding"), msg.Body, &htmlParts, &textParts); err != nil {
+		return nil, fmt.Errorf("failed to read email %q: %w", msg.Header.Get("Subject"), err)
+	}
+
+	var links []alertLink
+	for _, part := range htmlParts {
+		doc, err := goquery.NewDocumentFromReader(strings.NewReader(part))
+		if err != nil {
+			continue
+		}
+		doc.Find("a[href]").Each(func(_ int, a *goquery.Selection) {
+			text := collapseSpace(a.Text())
+			if alertLinkTextIgnored[strings.ToLower(text)] {
+				text = ""
+			}
+			links = append(links, alertLink{href: a.AttrOr("href", ""), text: text})
+		})
+	}
+	if len(htmlParts) == 0 {
+		for _, part := range textParts {
+			for _, href := range plainURLRe.FindAllString(part, -1) {
+				links = append(links, alertLink{href: href})
+			}
+		}
+	}
+	return links, nil
+}
+
+// walkMIME collects the decoded text/html and text/plain parts of a message body.
+func walkMIME(contentType, encoding string, body io.Reader, htmlParts, textParts *[]string) error {
+	mediaType, params, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		mediaType = "text/plain" // RFC 2045 default
+	}
+
+	if strings.HasPrefix(mediaType, "multipart/") {
+		mr := multipart.NewReader(body, params["boundary"])
+		for {
+			part, err := mr.NextPart()
+			if err == io.EOF {
+				return nil
+			}
+			if err != nil {
+				return err
+			}
+			// NextPart already decodes quoted-printable parts and drops the header
+			if err := walkMIME(part.Header.Get("Content-Type"), part.Header.Get("Content-Transfer-Encoding"), part, htmlParts, textParts); err != nil {
+				return err
+			}
+		}
+	}
+
+	switch strings.ToLower(strings.TrimSpace(encoding)) {
+	case "quoted-printable":
+		body = quotedprintable.NewReader(body)
+	case "base64":
+		body = base64.NewDecoder(base64.StdEncoding, body) // skips line breaks
+	}
+	data, err := io.ReadAll(body)
+	if err != nil {
+		return err
+	}
+
+	switch mediaType {
+	case "text/html":
+		*htmlParts = append(*htmlParts, string(data))
+	case "text/plain":
+		*textParts = append(*textParts, string(data))
+	}
+	return nil
+}
+
+// alertJob turns a job board link from an alert email into a job with a
+// canonical URL, or returns nil for links that aren't job postings.
+// Tracking redirects that carry the real link in a query parameter are
+// unwrapped first.
+func alertJob(href string) *database.Job {
+	u, err := url.Parse(strings.TrimSpace(href))
+	if err != nil || u.Host == "" {
+		return nil
+	}
+	for _, v := range u.Query() {
+		if len(v) > 0 && strings.HasPrefix(v[0], "http") {
+			if inner := alertJob(v[0]); inner != nil {
+				return inner
+			}
+		}
+	}
+
+	host := strings.ToLower(u.Hostname())
+	var source, canonical string
+	switch {
+	case strings.HasSuffix(host, "seek.com.au") && strings.Contains(u.Path, "/job/"):
+		source = "seek"
+		canonical = "https://www.seek.com.au/job/" + lastPathSegment(u.Path, "job")
+	case strings.HasSuffix(host, "linkedin.com") && strings.Contains(u.Path, "/jobs/view/"):
+		source = "linkedin"
+		canonical = "https://www.linkedin.com/jobs/view/" + lastPathSegment(u.Path, "view")
+	case strings.HasSuffix(host, "indeed.com") && u.Query().Get("jk") != "":
+		source = "indeed"
+		canonical = "https://au.indeed.com/viewjob?jk=" + u.Query().Get("jk")
+	default:
+		return nil
+	}
+
+	id := extractJobID(canonical)
+	if id == "" || strings.HasSuffix(id, "-") {
+		return nil
+	}
+	return &database.Job{
+		Source:     source,
+		URL:        canonical,
+		Status:     "discovered",
+		ExternalID: id,
+	}
+}
+
+// lastPathSegment returns the path segment that follows marker.
+func lastPathSegment(path, marker string) string {
+	parts := strings.Split(strings.Trim(path, "/"), "/")
+	for i, part := range parts {
+		if part == marker && i+1 < len(parts) {
+			return parts[i+1]
+		}
+	}
+	return ""
+}
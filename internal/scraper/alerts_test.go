@@ -0,0 +1,90 @@
+package scraper
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/guidebee/jobseeker/internal/database"
+)
+
+func TestParseAlertsMbox(t *testing.T) {
+	jobs, err := ParseAlerts(filepath.Join("testdata", "alerts.mbox"))
+	if err != nil {
+		t.Fatalf("ParseAlerts failed: %v", err)
+	}
+
+	want := []struct {
+		source, externalID, url, title string
+	}{
+		{"seek", "seek-81234567", "https://www.seek.com.au/job/81234567", "Senior Golang Engineer"},
+		{"seek", "seek-81234599", "https://www.seek.com.au/job/81234599", "Contract React Developer"},
+		{"linkedin", "linkedin-4381775795", "https://www.linkedin.com/jobs/view/4381775795", "AI Engineer"},
+		{"indeed", "indeed-5f1e2d3c4b5a6978", "https://au.indeed.com/viewjob?jk=5f1e2d3c4b5a6978", ""},
+	}
+	if len(jobs) != len(want) {
+		for _, j := range jobs {
+			t.Logf("got %s %s %q", j.Source, j.URL, j.Title)
+		}
+		t.Fatalf("expected %d jobs, got %d", len(want), len(jobs))
+	}
+	for i, w := range want {
+		job := jobs[i]
+		if job.Source != w.source || job.ExternalID != w.externalID || job.URL != w.url || job.Title != w.title {
+			t.Errorf("job %d = %s %s %s %q, want %s %s %s %q", i,
+				job.Source, job.ExternalID, job.URL, job.Title, w.source, w.externalID, w.url, w.title)
+		}
+	}
+	if jobs[1].JobType != "contract" {
+		t.Errorf("job type = %q, want contract", jobs[1].JobType)
+	}
+}
+
+func TestParseAlertsEmlDirectory(t *testing.T) {
+	jobs, err := ParseAlerts(filepath.Join("testdata", "alerts_eml"))
+	if err != nil {
+		t.Fatalf("ParseAlerts failed: %v", err)
+	}
+	if len(jobs) != 1 || jobs[0].ExternalID != "linkedin-4381775795" {
+		t.Fatalf("unexpected jobs: %+v", jobs)
+	}
+}
+
+func TestSplitMailboxUnquotesFrom(t *testing.T) {
+	raw := "From a@example.com Mon Jan  1 00:00:00 2025\nSubject: one\n\n>From here\n\nFrom b@example.com Mon Jan  1 00:00:00 2025\nSubject: two\n\nbody\n"
+	messages := splitMailbox([]byte(raw))
+	if len(messages) != 2 {
+		t.Fatalf("expected 2 messages, got %d", len(messages))
+	}
+	if got := string(messages[0]); got != "Subject: one\n\nFrom here\n\n" {
+		t.Errorf("first message = %q", got)
+	}
+}
+
+func TestImportAlertsIdempotent(t *testing.T) {
+	if err := database.InitDB(filepath.Join(t.TempDir(), "alerts.db")); err != nil {
+		t.Fatalf("InitDB failed: %v", err)
+	}
+
+	for i := 0; i < 2; i++ {
+		jobs, err := ParseAlerts(filepath.Join("testdata", "alerts.mbox"))
+		if err != nil {
+			t.Fatalf("ParseAlerts failed: %v", err)
+		}
+		stats, err := SaveJobs(jobs, 1)
+		if err != nil {
+			t.Fatalf("SaveJobs failed: %v", err)
+		}
+		if i == 1 && (stats.New != 0 || stats.Duplicates != 4) {
+			t.Errorf("second import saved %+v, want only duplicates", stats)
+		}
+	}
+
+	var count int64
+	database.GetDB().Model(&database.Job{}).Where("user_id = ?", 1).Count(&count)
+	if count != 4 {
+		t.Errorf("expected 4 stored jobs after importing twice, got %d", count)
+	}
+	if !JobExists("seek-81234567", 1) {
+		t.Error("expected the SEEK job to be stored")
+	}
+}
@@ -0,0 +1,384 @@
+package scraper
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"html"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/PuerkitoBio/goquery"
+	"github.com/guidebee/jobseeker/internal/database"
+	"github.com/guidebee/jobseeker/internal/profile"
+)
+
+// Public job-board API endpoints of the applicant tracking systems, and the
+// hosted boards whose page titles name the company for Lever and Ashby,
+// whose APIs don't
+const (
+	greenhouseAPIBase = "https://boards-api.greenhouse.io"
+	leverAPIBase      = "https://api.lever.co"
+	ashbyAPIBase      = "https://api.ashbyhq.com"
+	leverPageBase     = "https://jobs.lever.co"
+	ashbyPageBase     = "https://jobs.ashbyhq.com"
+)
+
+func init() {
+	RegisterBoard("greenhouse", func(s *Scraper, _ profile.JobBoard) Board {
+		return &greenhouseBoard{s: s, baseURL: greenhouseAPIBase}
+	})
+	RegisterBoard("lever", func(s *Scraper, _ profile.JobBoard) Board {
+		return &leverBoard{s: s, baseURL: leverAPIBase, pageURL: leverPageBase}
+	})
+	RegisterBoard("ashby", func(s *Scraper, _ profile.JobBoard) Board {
+		return &ashbyBoard{s: s, baseURL: ashbyAPIBase, pageURL: ashbyPageBase}
+	})
+}
+
+// getJSON fetches a public JSON endpoint and decodes it into v.
+func (s *Scraper) getJSON(apiURL string, v interface{}) error {
+	body, err := s.getBody(apiURL, "application/json")
+	if err != nil {
+		return err
+	}
+	if err := json.Unmarshal(body, v); err != nil {
+		return fmt.Errorf("failed to decode %s: %w", apiURL, err)
+	}
+	return nil
+}
+
+// getBody fetches a URL with the scraper's HTTP client and returns the body.
+func (s *Scraper) getBody(rawURL, accept string) ([]byte, error) {
+	req, err := http.NewRequest(http.MethodGet, rawURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("User-Agent", browserUA)
+	req.Header.Set("Accept", accept)
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		io.Copy(io.Discard, resp.Body) //nolint:errcheck
+		return nil, fmt.Errorf("%s returned %d", rawURL, resp.StatusCode)
+	}
+	return io.ReadAll(resp.Body)
+}
+
+// boardSlug accepts either a bare company slug ("canva") or a board URL
+// ("https://boards.greenhouse.io/canva") and returns the slug.
+func boardSlug(target string) string {
+	target = strings.TrimSpace(target)
+	if u, err := url.Parse(target); err == nil && u.Host != "" {
+		if parts := strings.Split(strings.Trim(u.Path, "/"), "/"); parts[0] != "" {
+			return parts[0]
+		}
+	}
+	return strings.Trim(target, "/")
+}
+
+// boardTitleNoiseRe matches the words around the company name in the title
+// of a hosted job board, e.g. "Jobs at " or " - Careers".
+var boardTitleNoiseRe = regexp.MustCompile(`(?i)^\s*(jobs|careers|open roles|open positions)\s+(at|@)\s+|\s*[-|–:]?\s*(jobs|careers|job board|open roles|open positions)\s*$`)
+
+// boardCompany returns the company name of a hosted job board from its page
+// title, falling back to slug when the page can't be read or has no title.
+func (s *Scraper) boardCompany(pageURL, slug string) string {
+	body, err := s.getBody(pageURL, "text/html")
+	if err != nil {
+		return slug
+	}
+	doc, err := goquery.NewDocumentFromReader(bytes.NewReader(body))
+	if err != nil {
+		return slug
+	}
+	name := strings.TrimSpace(boardTitleNoiseRe.ReplaceAllString(doc.Find("title").First().Text(), ""))
+	if name == "" {
+		return slug
+	}
+	return name
+}
+
+// atsHTMLToText converts an ATS description to plain text. Greenhouse
+// double-escapes its HTML, so entities are decoded on both sides.
+func atsHTMLToText(h string) string {
+	return html.UnescapeString(htmlToText(html.UnescapeString(h)))
+}
+
+// greenhouseBoard reads a company's Greenhouse job board.
+type greenhouseBoard struct {
+	s       *Scraper
+	baseURL string
+}
+
+type greenhouseJobs struct {
+	Jobs []struct {
+		ID          int64  `json:"id"`
+		Title       string `json:"title"`
+		AbsoluteURL string `json:"absolute_url"`
+		CompanyName string `json:"company_name"`
+		Content     string `json:"content"`
+		UpdatedAt   string `json:"updated_at"`
+		FirstPub    string `json:"first_published"`
+		Location    struct {
+			Name string `json:"name"`
+		} `json:"location"`
+	} `json:"jobs"`
+}
+
+func (b *greenhouseBoard) Name() string { return "greenhouse" }
+
+func (b *greenhouseBoard) Search(target string) ([]*database.Job, error) {
+	slug := boardSlug(target)
+	apiURL := fmt.Sprintf("%s/v1/boards/%s/jobs?content=true", b.baseURL, url.PathEscape(slug))
+
+	var resp greenhouseJobs
+	if err := b.s.getJSON(apiURL, &resp); err != nil {
+		return nil, fmt.Errorf("greenhouse: %w", err)
+	}
+
+	var jobs []*database.Job
+	for _, p := range resp.Jobs {
+		company := p.CompanyName
+		if company == "" {
+			company = slug
+		}
+		desc := atsHTMLToText(p.Content)
+		job := &database.Job{
+			Source:       "greenhouse",
+			URL:          p.AbsoluteURL,
+			Title:        strings.TrimSpace(p.Title),
+			Company:      company,
+			Location:     p.Location.Name,
+			Description:  desc,
+			Requirements: extractRequirements(desc),
+			Status:       "discovered",
+			ExternalID:   fmt.Sprintf("greenhouse-%d", p.ID),
+		}
+		job.ListedAt = parseTimestamp(p.FirstPub, p.UpdatedAt)
+		job.JobType = database.DetectJobType(job.Title, "", job.URL)
+		jobs = append(jobs, job)
+	}
+	return jobs, nil
+}
+
+// FetchDetail is a no-op: the board API already includes full descriptions.
+func (b *greenhouseBoard) FetchDetail(job *database.Job) error { return nil }
+
+func (b *greenhouseBoard) ExternalID(rawURL string) string { return extractJobID(rawURL) }
+
+// leverBoard reads a company's Lever postings.
+type leverBoard struct {
+	s       *Scraper
+	baseURL string
+	pageURL string // hosted boards, for the company name
+}
+
+type leverPosting struct {
+	ID         string `json:"id"`
+	Text       string `json:"text"`
+	HostedURL  string `json:"hostedUrl"`
+	CreatedAt  int64  `json:"createdAt"` // Unix milliseconds
+	Categories struct {
+		Location   string `json:"location"`
+		Commitment string `json:"commitment"`
+		Team       string `json:"team"`
+	} `json:"categories"`
+	DescriptionPlain string `json:"descriptionPlain"`
+	Lists            []struct {
+		Text    string `json:"text"`
+		Content string `json:"content"`
+	} `json:"lists"`
+	AdditionalPlain string `json:"additionalPlain"`
+	WorkplaceType   string `json:"workplaceType"`
+	SalaryRange     *struct {
+		Min      int    `json:"min"`
+		Max      int    `json:"max"`
+		Currency string `json:"currency"`
+		Interval string `json:"interval"`
+	} `json:"salaryRange"`
+}
+
+func (b *leverBoard) Name() string { return "lever" }
+
+func (b *leverBoard) Search(target string) ([]*database.Job, error) {
+	slug := boardSlug(target)
+	apiURL := fmt.Sprintf("%s/v0/postings/%s?mode=json", b.baseURL, url.PathEscape(slug))
+
+	var postings []leverPosting
+	if err := b.s.getJSON(apiURL, &postings); err != nil {
+		return nil, fmt.Errorf("lever: %w", err)
+	}
+
+	company := slug
+	if len(postings) > 0 {
+		company = b.s.boardCompany(b.pageURL+"/"+url.PathEscape(slug), slug)
+	}
+
+	var jobs []*database.Job
+	for _, p := range postings {
+		// The description is split into an intro, titled bullet lists and a closing section
+		sections := []string{strings.TrimSpace(p.DescriptionPlain)}
+		requirements := ""
+		for _, list := range p.Lists {
+			items := atsHTMLToText(list.Content)
+			sections = append(sections, list.Text+"\n"+items)
+			if requirements == "" && isHeading(list.Text, requirementHeadings) {
+				requirements = items
+			}
+		}
+		sections = append(sections, strings.TrimSpace(p.AdditionalPlain))
+
+		location := p.Categories.Location
+		if p.WorkplaceType == "remote" && !strings.Contains(strings.ToLower(location), "remote") {
+			location = strings.TrimSpace(location + " (Remote)")
+		}
+
+		job := &database.Job{
+			Source:       "lever",
+			URL:          p.HostedURL,
+			Title:        strings.TrimSpace(p.Text),
+			Company:      company,
+			Location:     location,
+			Salary:       leverSalary(p),
+			WorkType:     p.Categories.Commitment,
+			Description:  strings.TrimSpace(strings.Join(sections, "\n\n")),
+			Requirements: requirements,
+			Status:       "discovered",
+			ExternalID:   "lever-" + p.ID,
+		}
+		if p.CreatedAt > 0 {
+			listed := time.UnixMilli(p.CreatedAt)
+			job.ListedAt = &listed
+		}
+		job.JobType = database.DetectJobType(job.Title, job.Salary+" "+job.WorkType, job.URL)
+		jobs = append(jobs, job)
+	}
+	return jobs, nil
+}
+
+// leverSalary formats a posting's salary range, e.g. "AUD 150000 - 180000 per year".
+func leverSalary(p leverPosting) string {
+	if p.SalaryRange == nil || p.SalaryRange.Max == 0 {
+		return ""
+	}
+	interval := strings.NewReplacer("-salary", "", "-wage", "", "-", " ").Replace(p.SalaryRange.Interval)
+	return strings.TrimSpace(fmt.Sprintf("%s %d - %d %s",
+		p.SalaryRange.Currency, p.SalaryRange.Min, p.SalaryRange.Max, interval))
+}
+
+// FetchDetail is a no-op: the postings API already includes full descriptions.
+func (b *leverBoard) FetchDetail(job *database.Job) error { return nil }
+
+func (b *leverBoard) ExternalID(rawURL string) string { return extractJobID(rawURL) }
+
+// ashbyBoard reads a company's Ashby job board.
+type ashbyBoard struct {
+	s       *Scraper
+	baseURL string
+	pageURL string // hosted boards, for the company name
+}
+
+type ashbyJobs struct {
+	Jobs []struct {
+		ID               string `json:"id"`
+		Title            string `json:"title"`
+		Location         string `json:"location"`
+		EmploymentType   string `json:"employmentType"` // "FullTime", "Contract", ...
+		IsRemote         bool   `json:"isRemote"`
+		JobURL           string `json:"jobUrl"`
+		DescriptionHTML  string `json:"descriptionHtml"`
+		DescriptionPlain string `json:"descriptionPlain"`
+		PublishedAt      string `json:"publishedAt"`
+		Compensation     *struct {
+			Summary string `json:"compensationTierSummary"`
+		} `json:"compensation"`
+	} `json:"jobs"`
+}
+
+// ashbyEmploymentTypes maps Ashby's enum values to readable work types
+var ashbyEmploymentTypes = map[string]string{
+	"FullTime":   "Full time",
+	"PartTime":   "Part time",
+	"Contract":   "Contract",
+	"Temporary":  "Temporary",
+	"Intern":     "Internship",
+	"Internship": "Internship",
+}
+
+func (b *ashbyBoard) Name() string { return "ashby" }
+
+func (b *ashbyBoard) Search(target string) ([]*database.Job, error) {
+	slug := boardSlug(target)
+	apiURL := fmt.Sprintf("%s/posting-api/job-board/%s?includeCompensation=true", b.baseURL, url.PathEscape(slug))
+
+	var resp ashbyJobs
+	if err := b.s.getJSON(apiURL, &resp); err != nil {
+		return nil, fmt.Errorf("ashby: %w", err)
+	}
+
+	company := slug
+	if len(resp.Jobs) > 0 {
+		company = b.s.boardCompany(b.pageURL+"/"+url.PathEscape(slug), slug)
+	}
+
+	var jobs []*database.Job
+	for _, p := range resp.Jobs {
+		desc := strings.TrimSpace(p.DescriptionPlain)
+		if desc == "" {
+			desc = atsHTMLToText(p.DescriptionHTML)
+		}
+
+		location := p.Location
+		if p.IsRemote && !strings.Contains(strings.ToLower(location), "remote") {
+			location = strings.TrimSpace(location + " (Remote)")
+		}
+
+		salary := ""
+		if p.Compensation != nil {
+			salary = p.Compensation.Summary
+		}
+
+		job := &database.Job{
+			Source:       "ashby",
+			URL:          p.JobURL,
+			Title:        strings.TrimSpace(p.Title),
+			Company:      company,
+			Location:     location,
+			Salary:       salary,
+			WorkType:     ashbyEmploymentTypes[p.EmploymentType],
+			Description:  desc,
+			Requirements: extractRequirements(desc),
+			Status:       "discovered",
+			ExternalID:   "ashby-" + p.ID,
+		}
+		job.ListedAt = parseTimestamp(p.PublishedAt)
+		job.JobType = database.DetectJobType(job.Title, job.Salary+" "+job.WorkType, job.URL)
+		jobs = append(jobs, job)
+	}
+	return jobs, nil
+}
+
+// FetchDetail is a no-op: the board API already includes full descriptions.
+func (b *ashbyBoard) FetchDetail(job *database.Job) error { return nil }
+
+func (b *ashbyBoard) ExternalID(rawURL string) string { return extractJobID(rawURL) }
+
+// parseTimestamp returns the first candidate that parses as an RFC 3339 time.
+func parseTimestamp(candidates ...string) *time.Time {
+	for _, c := range candidates {
+		if t, err := time.Parse(time.RFC3339, c); err == nil {
+			return &t
+		}
+	}
+	return nil
+}
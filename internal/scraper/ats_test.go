@@ -0,0 +1,179 @@
+package scraper
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// serveFixture returns a test server that answers requests for path with a
+// recorded JSON response from testdata, and for each pair of pages with the
+// HTML of the second for the path of the first.
+func serveFixture(t *testing.T, path, fixture string, pages ...string) *httptest.Server {
+	t.Helper()
+	body, err := os.ReadFile(filepath.Join("testdata", fixture))
+	if err != nil {
+		t.Fatalf("failed to read fixture: %v", err)
+	}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		for i := 0; i+1 < len(pages); i += 2 {
+			if r.URL.Path == pages[i] {
+				w.Header().Set("Content-Type", "text/html")
+				w.Write([]byte(pages[i+1])) //nolint:errcheck
+				return
+			}
+		}
+		if r.URL.Path != path {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(body) //nolint:errcheck
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestGreenhouseBoard(t *testing.T) {
+	srv := serveFixture(t, "/v1/boards/examplefin/jobs", "greenhouse_jobs.json")
+	b := &greenhouseBoard{s: NewScraper(0), baseURL: srv.URL}
+
+	jobs, err := b.Search("https://boards.greenhouse.io/examplefin")
+	if err != nil {
+		t.Fatalf("Search failed: %v", err)
+	}
+	if len(jobs) != 1 {
+		t.Fatalf("expected 1 job, got %d", len(jobs))
+	}
+
+	job := jobs[0]
+	if job.Source != "greenhouse" || job.ExternalID != "greenhouse-5512345" {
+		t.Errorf("unexpected source/id: %s %s", job.Source, job.ExternalID)
+	}
+	if job.Company != "ExampleFin" {
+		t.Errorf("company = %q", job.Company)
+	}
+	if !strings.Contains(job.Description, "small businesses & their customers") {
+		t.Errorf("description not unescaped: %q", job.Description)
+	}
+	if job.Requirements != "6+ years building services in Go\nPostgreSQL and Kafka" {
+		t.Errorf("requirements = %q", job.Requirements)
+	}
+	if job.ListedAt == nil || job.ListedAt.UTC().Format("2006-01-02") != "2025-03-01" {
+		t.Errorf("listed at = %v", job.ListedAt)
+	}
+	if got := b.ExternalID(job.URL); got != job.ExternalID {
+		t.Errorf("ExternalID(url) = %q, want %q", got, job.ExternalID)
+	}
+}
+
+func TestLeverBoard(t *testing.T) {
+	srv := serveFixture(t, "/v0/postings/examplehealth", "lever_postings.json",
+		"/examplehealth", "<html><head><title>Example Health</title></head></html>")
+	b := &leverBoard{s: NewScraper(0), baseURL: srv.URL, pageURL: srv.URL}
+
+	jobs, err := b.Search("examplehealth")
+	if err != nil {
+		t.Fatalf("Search failed: %v", err)
+	}
+	if len(jobs) != 1 {
+		t.Fatalf("expected 1 job, got %d", len(jobs))
+	}
+
+	job := jobs[0]
+	if job.Source != "lever" || job.ExternalID != "lever-0f5c6a2e-7c1b-4a8e-9d7f-2b3c4d5e6f70" {
+		t.Errorf("unexpected source/id: %s %s", job.Source, job.ExternalID)
+	}
+	if job.Company != "Example Health" {
+		t.Errorf("company = %q, want the board's title", job.Company)
+	}
+	if job.Location != "Sydney (Remote)" {
+		t.Errorf("location = %q", job.Location)
+	}
+	if job.Salary != "AUD 900 - 1100 per day" || job.JobType != "contract" {
+		t.Errorf("salary/type = %q / %q", job.Salary, job.JobType)
+	}
+	if job.Requirements != "Python and PyTorch\nMLOps on AWS" {
+		t.Errorf("requirements = %q", job.Requirements)
+	}
+	if !strings.Contains(job.Description, "Healthcare data experience") {
+		t.Errorf("description missing list sections: %q", job.Description)
+	}
+	if got := b.ExternalID(job.URL); got != job.ExternalID {
+		t.Errorf("ExternalID(url) = %q, want %q", got, job.ExternalID)
+	}
+}
+
+func TestAshbyBoard(t *testing.T) {
+	srv := serveFixture(t, "/posting-api/job-board/exampleai", "ashby_jobs.json",
+		"/exampleai", "<html><head><title>Jobs at Example AI</title></head></html>")
+	b := &ashbyBoard{s: NewScraper(0), baseURL: srv.URL, pageURL: srv.URL}
+
+	jobs, err := b.Search("exampleai")
+	if err != nil {
+		t.Fatalf("Search failed: %v", err)
+	}
+	if len(jobs) != 1 {
+		t.Fatalf("expected 1 job, got %d", len(jobs))
+	}
+
+	job := jobs[0]
+	if job.Source != "ashby" || job.ExternalID != "ashby-7d1e2f3a-4b5c-6d7e-8f90-a1b2c3d4e5f6" {
+		t.Errorf("unexpected source/id: %s %s", job.Source, job.ExternalID)
+	}
+	if job.Company != "Example AI" {
+		t.Errorf("company = %q, want the board's title without \"Jobs at\"", job.Company)
+	}
+	if job.WorkType != "Full time" || job.JobType != "permanent" {
+		t.Errorf("work type/job type = %q / %q", job.WorkType, job.JobType)
+	}
+	if job.Salary != "A$180K – A$210K • Offers Equity" {
+		t.Errorf("salary = %q", job.Salary)
+	}
+	if job.Requirements != "Shipped LLM features to production\nStrong TypeScript or Go" {
+		t.Errorf("requirements = %q", job.Requirements)
+	}
+	if got := b.ExternalID(job.URL); got != job.ExternalID {
+		t.Errorf("ExternalID(url) = %q, want %q", got, job.ExternalID)
+	}
+}
+
+func TestBoardCompanyFallsBackToSlug(t *testing.T) {
+	srv := serveFixture(t, "/v0/postings/examplehealth", "lever_postings.json",
+		"/untitled", "<html><head><title> Careers </title></head></html>")
+	s := NewScraper(0)
+
+	if got := s.boardCompany(srv.URL+"/examplehealth-board", "examplehealth"); got != "examplehealth" {
+		t.Errorf("company of a missing board page = %q, want the slug", got)
+	}
+	if got := s.boardCompany(srv.URL+"/untitled", "untitled"); got != "untitled" {
+		t.Errorf("company of a board titled only \"Careers\" = %q, want the slug", got)
+	}
+}
+
+func TestATSBoardNotFound(t *testing.T) {
+	srv := serveFixture(t, "/v1/boards/examplefin/jobs", "greenhouse_jobs.json")
+	b := &greenhouseBoard{s: NewScraper(0), baseURL: srv.URL}
+
+	if _, err := b.Search("missing-company"); err == nil {
+		t.Error("expected an error for an unknown board slug")
+	}
+}
+
+func TestExtractJobIDATS(t *testing.T) {
+	tests := []struct{ url, want string }{
+		{"https://boards.greenhouse.io/examplefin/jobs/5512345", "greenhouse-5512345"},
+		{"https://job-boards.greenhouse.io/examplefin/jobs/5512345?gh_src=abc", "greenhouse-5512345"},
+		// A Greenhouse board embedded on the company's own site
+		{"https://examplefin.com/careers?gh_jid=5512345", "greenhouse-5512345"},
+		{"https://jobs.lever.co/examplehealth/0f5c6a2e-7c1b-4a8e-9d7f-2b3c4d5e6f70", "lever-0f5c6a2e-7c1b-4a8e-9d7f-2b3c4d5e6f70"},
+	}
+	for _, tt := range tests {
+		if got := extractJobID(tt.url); got != tt.want {
+			t.Errorf("extractJobID(%q) = %q, want %q", tt.url, got, tt.want)
+		}
+	}
+}
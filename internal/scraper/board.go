@@ -0,0 +1,160 @@
+package scraper
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/gocolly/colly/v2"
+	"github.com/guidebee/jobseeker/internal/database"
+	"github.com/guidebee/jobseeker/internal/profile"
+)
+
+// Board is a job source that the scan command can drive generically.
+// Adding a new job board means implementing Board and registering it with
+// RegisterBoard under the same key that is used in config.yaml job_boards.
+type Board interface {
+	// Name is the config key under job_boards and the Job.Source value.
+	Name() string
+
+	// Search returns the jobs listed at a search target (usually a search URL).
+	Search(target string) ([]*database.Job, error)
+
+	// FetchDetail fills in the fields that only the job's own page provides,
+	// such as the full description. Boards without a detail page return nil.
+	FetchDetail(job *database.Job) error
+
+	// ExternalID derives the board's stable job ID from a job URL.
+	ExternalID(rawURL string) string
+}
+
+// SelectorMisser is implemented by boards whose Search can succeed while
+// some of a page's selectors miss, such as a title missing from a few cards.
+// SelectorMisses describes what missed on the target's last Search, so a
+// scan can show it beside the target's job count.
+type SelectorMisser interface {
+	SelectorMisses(target string) []string
+}
+
+// BoardFactory builds a Board that scrapes through the given Scraper using
+// the board's entry from config.yaml job_boards.
+type BoardFactory func(s *Scraper, cfg profile.JobBoard) Board
+
+var (
+	boardsMu sync.RWMutex
+	boards   = make(map[string]BoardFactory)
+)
+
+// RegisterBoard makes a board available under name. Registering the same
+// name twice is a programming error and panics.
+func RegisterBoard(name string, factory BoardFactory) {
+	boardsMu.Lock()
+	defer boardsMu.Unlock()
+
+	if _, dup := boards[name]; dup {
+		panic("scraper: board registered twice: " + name)
+	}
+	boards[name] = factory
+}
+
+// NewBoard creates the board registered under name.
+func NewBoard(name string, s *Scraper, cfg profile.JobBoard) (Board, error) {
+	boardsMu.RLock()
+	factory, ok := boards[name]
+	boardsMu.RUnlock()
+
+	if !ok {
+		return nil, fmt.Errorf("no scraper registered for job board %q", name)
+	}
+	return factory(s, cfg), nil
+}
+
+// RegisteredBoards returns the names of all registered boards in sorted order.
+func RegisteredBoards() []string {
+	boardsMu.RLock()
+	defer boardsMu.RUnlock()
+
+	names := make([]string, 0, len(boards))
+	for name := range boards {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func init() {
+	RegisterBoard("seek", func(s *Scraper, cfg profile.JobBoard) Board {
+		return &seekBoard{s: s, pages: paginationFor(cfg)}
+	})
+	RegisterBoard("linkedin", func(s *Scraper, cfg profile.JobBoard) Board {
+		return &linkedInBoard{s: s, pages: paginationFor(cfg)}
+	})
+	RegisterBoard("indeed", func(s *Scraper, cfg profile.JobBoard) Board {
+		return &indeedBoard{s: s, pages: paginationFor(cfg)}
+	})
+}
+
+// paginationFor reads the pagination limits from a board's config entry.
+func paginationFor(cfg profile.JobBoard) Pagination {
+	return Pagination{MaxPages: cfg.MaxPages, MaxJobs: cfg.MaxJobs}
+}
+
+// seekBoard adapts the SEEK scraper to the Board interface.
+type seekBoard struct {
+	s     *Scraper
+	pages Pagination
+
+	detailOnce sync.Once
+	detail     *colly.Collector // shared so its LimitRule spaces out detail fetches
+}
+
+func (b *seekBoard) Name() string { return "seek" }
+
+func (b *seekBoard) Search(target string) ([]*database.Job, error) {
+	return b.s.ScrapeSeekPages(target, b.pages)
+}
+
+// FetchDetail visits the SEEK job page for the full ad body, work type,
+// classification and listing date.
+func (b *seekBoard) FetchDetail(job *database.Job) error {
+	b.detailOnce.Do(func() { b.detail = b.s.newSeekDetailCollector() })
+	return fetchSeekDetail(b.detail, job)
+}
+
+func (b *seekBoard) ExternalID(rawURL string) string { return extractJobID(rawURL) }
+
+// linkedInBoard adapts the LinkedIn guest API scraper to the Board interface.
+type linkedInBoard struct {
+	s     *Scraper
+	pages Pagination
+}
+
+func (b *linkedInBoard) Name() string { return "linkedin" }
+
+func (b *linkedInBoard) Search(target string) ([]*database.Job, error) {
+	return b.s.SearchLinkedInPages(target, b.pages)
+}
+
+// FetchDetail loads the full description from the guest jobPosting API.
+func (b *linkedInBoard) FetchDetail(job *database.Job) error {
+	return b.s.fetchLinkedInDetail(job)
+}
+
+func (b *linkedInBoard) ExternalID(rawURL string) string { return extractJobID(rawURL) }
+
+// indeedBoard adapts the Indeed scraper to the Board interface.
+type indeedBoard struct {
+	s     *Scraper
+	pages Pagination
+}
+
+func (b *indeedBoard) Name() string { return "indeed" }
+
+func (b *indeedBoard) Search(target string) ([]*database.Job, error) {
+	return b.s.ScrapeIndeedPages(target, b.pages)
+}
+
+// FetchDetail is a no-op: Indeed detail pages are not scraped.
+func (b *indeedBoard) FetchDetail(job *database.Job) error { return nil }
+
+func (b *indeedBoard) ExternalID(rawURL string) string { return extractJobID(rawURL) }
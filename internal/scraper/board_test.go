@@ -0,0 +1,27 @@
+package scraper
+
+import (
+	"testing"
+
+	"github.com/guidebee/jobseeker/internal/profile"
+)
+
+// TestRegisteredBoards checks that the built-in job boards are registered
+// under the keys used in config.yaml.
+func TestRegisteredBoards(t *testing.T) {
+	s := NewScraper(0)
+
+	for _, name := range []string{"seek", "linkedin", "indeed"} {
+		board, err := NewBoard(name, s, profile.JobBoard{})
+		if err != nil {
+			t.Fatalf("NewBoard(%q) failed: %v", name, err)
+		}
+		if board.Name() != name {
+			t.Errorf("board registered as %q reports name %q", name, board.Name())
+		}
+	}
+
+	if _, err := NewBoard("no-such-board", s, profile.JobBoard{}); err == nil {
+		t.Error("expected an error for an unregistered board")
+	}
+}
@@ -0,0 +1,286 @@
+package scraper
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+
+	"github.com/PuerkitoBio/goquery"
+	"github.com/gocolly/colly/v2"
+	"github.com/guidebee/jobseeker/internal/database"
+	"github.com/guidebee/jobseeker/internal/profile"
+)
+
+func init() {
+	RegisterBoard("careers", func(s *Scraper, cfg profile.JobBoard) Board {
+		b := &careersBoard{
+			s:      s,
+			sites:  make(map[string]profile.CareerSite, len(cfg.Sites)),
+			siteOf: make(map[string]profile.CareerSite),
+			misses: make(map[string][]string),
+		}
+		for _, site := range cfg.Sites {
+			b.sites[site.Name] = site
+		}
+		return b
+	})
+}
+
+// careersBoard scrapes employer careers pages described by CSS selectors in
+// config.yaml, so a new employer can be tracked without writing Go. Its
+// search targets are the site names.
+type careersBoard struct {
+	s     *Scraper
+	sites map[string]profile.CareerSite
+
+	once sync.Once
+	c    *colly.Collector // shared so its LimitRule spaces out every request
+
+	mu     sync.Mutex
+	siteOf map[string]profile.CareerSite // job URL → site, for FetchDetail
+	misses map[string][]string           // site name → selectors that missed some cards
+}
+
+func (b *careersBoard) Name() string { return "careers" }
+
+func (b *careersBoard) collector() *colly.Collector {
+	b.once.Do(func() { b.c = b.s.newPageCollector() })
+	return b.c
+}
+
+func (b *careersBoard) Search(target string) ([]*database.Job, error) {
+	site, ok := b.sites[target]
+	if !ok {
+		return nil, fmt.Errorf("careers: no site named %q under sites", target)
+	}
+	if site.URL == "" || site.Card == "" || site.Title == "" {
+		return nil, fmt.Errorf("careers %s: url, card and title are required", site.Name)
+	}
+
+	doc, err := fetchDocument(b.collector(), site.URL)
+	if err != nil {
+		return nil, fmt.Errorf("careers %s: %w", site.Name, err)
+	}
+
+	jobs, failures := parseCareerCards(site, doc)
+	if len(jobs) == 0 {
+		page, _ := doc.Html()
+		return nil, b.s.selectorsBroken("careers", site.URL, site.Name+": "+strings.Join(failures, "; "), []byte(page))
+	}
+	b.mu.Lock()
+	for _, job := range jobs {
+		b.siteOf[job.URL] = site
+	}
+	b.misses[site.Name] = failures
+	b.mu.Unlock()
+
+	return jobs, nil
+}
+
+// FetchDetail reads the description from the job's own page when the site
+// configures a description selector.
+func (b *careersBoard) FetchDetail(job *database.Job) error {
+	b.mu.Lock()
+	site, ok := b.siteOf[job.URL]
+	b.mu.Unlock()
+	if !ok || site.Description == "" {
+		return nil
+	}
+
+	doc, err := fetchDocument(b.collector(), job.URL)
+	if err != nil {
+		return err
+	}
+
+	descHTML, err := doc.Find(site.Description).First().Html()
+	if err != nil {
+		return err
+	}
+	desc := htmlToText(descHTML)
+	if desc == "" {
+		return fmt.Errorf("description selector %q matched nothing", site.Description)
+	}
+	job.Description = desc
+	job.Requirements = extractRequirements(desc)
+	return nil
+}
+
+func (b *careersBoard) ExternalID(rawURL string) string { return careersJobID(rawURL) }
+
+// SelectorMisses returns the selectors that failed to match some of the
+// cards of the site's last Search.
+func (b *careersBoard) SelectorMisses(target string) []string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.misses[target]
+}
+
+// careersJobID keeps the ATS ID for links to a known board, so a job found on
+// both the careers page and its Greenhouse board is stored once.
+func careersJobID(rawURL string) string {
+	if id := extractJobID(rawURL); id != rawURL {
+		return id
+	}
+	if u, err := url.Parse(rawURL); err == nil {
+		u.Fragment = ""
+		rawURL = u.String()
+	}
+	return "careers-" + rawURL
+}
+
+// parseCareerCards turns the cards on a careers page into jobs. Alongside
+// the jobs it returns a description of every selector that failed to match.
+func parseCareerCards(site profile.CareerSite, doc *goquery.Document) ([]*database.Job, []string) {
+	cards := doc.Find(site.Card)
+	if cards.Length() == 0 {
+		return nil, []string{fmt.Sprintf("card selector %q matched nothing", site.Card)}
+	}
+
+	company := site.Company
+	if company == "" {
+		company = site.Name
+	}
+
+	var jobs []*database.Job
+	seen := make(map[string]bool)
+	noTitle, noLink, noLocation := 0, 0, 0
+
+	cards.Each(func(_ int, card *goquery.Selection) {
+		titleSel := card.Find(site.Title).First()
+		title := collapseSpace(titleSel.Text())
+		if title == "" {
+			noTitle++
+			return
+		}
+
+		link := careerLink(site, card, titleSel)
+		if link == "" {
+			noLink++
+			return
+		}
+		if doc.Url != nil {
+			if ref, err := url.Parse(link); err == nil {
+				link = doc.Url.ResolveReference(ref).String()
+			}
+		}
+		if seen[link] {
+			return
+		}
+		seen[link] = true
+
+		location := ""
+		if site.Location != "" {
+			location = collapseSpace(card.Find(site.Location).First().Text())
+			if location == "" {
+				noLocation++
+			}
+		}
+
+		// The card text stands in for the description until the job page is read
+		cardHTML, _ := card.Html()
+		job := &database.Job{
+			Source:      "careers",
+			URL:         link,
+			Title:       title,
+			Company:     company,
+			Location:    location,
+			Description: htmlToText(cardHTML),
+			Status:      "discovered",
+			ExternalID:  careersJobID(link),
+		}
+		job.JobType = database.DetectJobType(job.Title, "", job.URL)
+		jobs = append(jobs, job)
+	})
+
+	total := cards.Length()
+	var failures []string
+	if noTitle > 0 {
+		failures = append(failures, fmt.Sprintf("title selector %q matched nothing in %d of %d cards", site.Title, noTitle, total))
+	}
+	if noLink > 0 {
+		failures = append(failures, fmt.Sprintf("no job link (selector %q) in %d of %d cards", site.Link, noLink, total))
+	}
+	if noLocation > 0 {
+		failures = append(failures, fmt.Sprintf("location selector %q matched nothing in %d of %d cards", site.Location, noLocation, total))
+	}
+	return jobs, failures
+}
+
+// careerLink finds a card's job link: the configured link selector, else the
+// title's own anchor, else the card itself or the first anchor inside it.
+func careerLink(site profile.CareerSite, card, title *goquery.Selection) string {
+	candidates := []*goquery.Selection{}
+	if site.Link != "" {
+		candidates = append(candidates, card.Find(site.Link).First())
+	} else {
+		candidates = append(candidates,
+			title.Closest("a[href]"),
+			title.Find("a[href]").First(),
+			card.Filter("a[href]"),
+			card.Find("a[href]").First(),
+		)
+	}
+	for _, sel := range candidates {
+		if href, ok := sel.Attr("href"); ok && strings.TrimSpace(href) != "" {
+			return strings.TrimSpace(href)
+		}
+	}
+	return ""
+}
+
+// collapseSpace trims text and collapses inner runs of whitespace.
+func collapseSpace(text string) string {
+	return strings.Join(strings.Fields(text), " ")
+}
+
+// newPageCollector creates a collector for arbitrary sites that hands back
+// parsed documents through each request's context (see fetchDocument).
+func (s *Scraper) newPageCollector() *colly.Collector {
+	c := s.newSiteCollector(&colly.LimitRule{
+		DomainGlob:  "*",
+		Delay:       s.delay,
+		Parallelism: 1,
+	})
+	handDocuments(c)
+	return c
+}
+
+// handDocuments makes c store each parsed response, or the error that
+// prevented it, in the request's context for fetchDocument.
+func handDocuments(c *colly.Collector) {
+	c.OnError(func(r *colly.Response, err error) {
+		r.Ctx.Put("err", fmt.Errorf("%s returned %d: %w", r.Request.URL, r.StatusCode, err))
+	})
+
+	c.OnResponse(func(r *colly.Response) {
+		doc, err := goquery.NewDocumentFromReader(bytes.NewReader(r.Body))
+		if err != nil {
+			r.Ctx.Put("err", fmt.Errorf("%s: %w", r.Request.URL, err))
+			return
+		}
+		doc.Url = r.Request.URL
+		r.Ctx.Put("doc", doc)
+	})
+}
+
+// fetchDocument visits pageURL through a page collector and returns the
+// parsed page.
+func fetchDocument(c *colly.Collector, pageURL string) (*goquery.Document, error) {
+	ctx := colly.NewContext()
+	if err := c.Request(http.MethodGet, pageURL, nil, ctx, nil); err != nil {
+		return nil, fmt.Errorf("failed to visit %s: %w", pageURL, err)
+	}
+	c.Wait()
+
+	if err, ok := ctx.GetAny("err").(error); ok {
+		return nil, err
+	}
+	doc, ok := ctx.GetAny("doc").(*goquery.Document)
+	if !ok {
+		return nil, fmt.Errorf("no response from %s", pageURL)
+	}
+	return doc, nil
+}
@@ -0,0 +1,132 @@
+package scraper
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/guidebee/jobseeker/internal/profile"
+)
+
+func newCareersServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	mux := http.NewServeMux()
+	mux.HandleFunc("/careers", func(w http.ResponseWriter, r *http.Request) {
+		http.ServeFile(w, r, "testdata/careers_listing.html")
+	})
+	mux.HandleFunc("/careers/jobs/", func(w http.ResponseWriter, r *http.Request) {
+		http.ServeFile(w, r, "testdata/careers_job.html")
+	})
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func newTestCareersBoard(t *testing.T, site profile.CareerSite) Board {
+	t.Helper()
+	b, err := NewBoard("careers", NewScraper(0), profile.JobBoard{Sites: []profile.CareerSite{site}})
+	if err != nil {
+		t.Fatalf("NewBoard failed: %v", err)
+	}
+	return b
+}
+
+func TestCareersBoard(t *testing.T) {
+	srv := newCareersServer(t)
+	b := newTestCareersBoard(t, profile.CareerSite{
+		Name:        "examplebank",
+		Company:     "Example Bank",
+		URL:         srv.URL + "/careers",
+		Card:        "li.vacancy",
+		Title:       ".vacancy-title",
+		Location:    ".vacancy-location",
+		Description: ".job-description",
+	})
+
+	jobs, err := b.Search("examplebank")
+	if err != nil {
+		t.Fatalf("Search failed: %v", err)
+	}
+	if len(jobs) != 3 {
+		t.Fatalf("expected 3 jobs (one card has no title), got %d", len(jobs))
+	}
+
+	first := jobs[0]
+	if first.Source != "careers" || first.Company != "Example Bank" {
+		t.Errorf("unexpected source/company: %s %s", first.Source, first.Company)
+	}
+	if first.URL != srv.URL+"/careers/jobs/1042-senior-data-engineer" {
+		t.Errorf("relative link not resolved: %s", first.URL)
+	}
+	if first.ExternalID != "careers-"+first.URL {
+		t.Errorf("external id = %s", first.ExternalID)
+	}
+	if first.Location != "Melbourne, VIC" {
+		t.Errorf("location = %q", first.Location)
+	}
+
+	if jobs[1].Location != "Docklands, VIC" || strings.Contains(jobs[1].ExternalID, "#") {
+		t.Errorf("unexpected second job: %q %s", jobs[1].Location, jobs[1].ExternalID)
+	}
+	if jobs[1].JobType != "contract" {
+		t.Errorf("job type = %q", jobs[1].JobType)
+	}
+	if jobs[2].ExternalID != "greenhouse-778899" {
+		t.Errorf("ATS link should keep its board ID, got %s", jobs[2].ExternalID)
+	}
+
+	if err := b.FetchDetail(first); err != nil {
+		t.Fatalf("FetchDetail failed: %v", err)
+	}
+	if strings.Contains(first.Description, "Careers") {
+		t.Errorf("description includes page chrome: %q", first.Description)
+	}
+	if first.Requirements != "Spark and Databricks\nPython" {
+		t.Errorf("requirements = %q", first.Requirements)
+	}
+}
+
+func TestCareersBoardPartialSelectorMiss(t *testing.T) {
+	srv := newCareersServer(t)
+	b := newTestCareersBoard(t, profile.CareerSite{
+		Name:  "examplebank",
+		URL:   srv.URL + "/careers",
+		Card:  "li.vacancy",
+		Title: ".vacancy-title",
+	})
+
+	// One card of four has no title: the site still yields jobs, and the miss
+	// is kept for the scan's per-site results
+	jobs, err := b.Search("examplebank")
+	if err != nil || len(jobs) != 3 {
+		t.Fatalf("Search = %d jobs, %v; want 3", len(jobs), err)
+	}
+	misses := b.(SelectorMisser).SelectorMisses("examplebank")
+	if len(misses) != 1 || !strings.Contains(misses[0], `title selector ".vacancy-title" matched nothing in 1 of 4 cards`) {
+		t.Errorf("misses = %q", misses)
+	}
+}
+
+func TestCareersBoardSelectorFailures(t *testing.T) {
+	srv := newCareersServer(t)
+	b := newTestCareersBoard(t, profile.CareerSite{
+		Name:  "examplebank",
+		URL:   srv.URL + "/careers",
+		Card:  "div.job-card",
+		Title: "h2",
+	})
+
+	_, err := b.Search("examplebank")
+	if err == nil || !strings.Contains(err.Error(), `card selector "div.job-card" matched nothing`) {
+		t.Errorf("expected a card selector failure, got %v", err)
+	}
+	if !errors.Is(err, ErrSelectorsBroken) {
+		t.Errorf("expected ErrSelectorsBroken, got %v", err)
+	}
+
+	if _, err := b.Search("unknown"); err == nil {
+		t.Error("expected an error for an unconfigured site")
+	}
+}
@@ -0,0 +1,55 @@
+package scraper
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/gocolly/colly/v2"
+)
+
+// TestSiteCollectorsShareLimitRule checks that collectors created for the same
+// site by concurrent scans are throttled together by the site's LimitRule.
+func TestSiteCollectorsShareLimitRule(t *testing.T) {
+	var inFlight, maxInFlight int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(&inFlight, 1)
+		for {
+			max := atomic.LoadInt32(&maxInFlight)
+			if n <= max || atomic.CompareAndSwapInt32(&maxInFlight, max, n) {
+				break
+			}
+		}
+		time.Sleep(20 * time.Millisecond)
+		atomic.AddInt32(&inFlight, -1)
+		w.Write([]byte("<html></html>")) //nolint:errcheck
+	}))
+	defer srv.Close()
+
+	u, _ := url.Parse(srv.URL)
+	s := NewScraper(0)
+	rule := &colly.LimitRule{DomainGlob: "*", Parallelism: 1}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			c := s.newSiteCollector(rule, u.Hostname())
+			c.Visit(srv.URL) //nolint:errcheck
+			c.Wait()
+		}()
+	}
+	wg.Wait()
+
+	if maxInFlight != 1 {
+		t.Errorf("expected requests to the site to be serialised, saw %d at once", maxInFlight)
+	}
+	if len(s.bases) != 1 {
+		t.Errorf("expected one base collector for the site, got %d", len(s.bases))
+	}
+}
@@ -0,0 +1,69 @@
+package scraper
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// relativeAgeRe matches job board age labels such as "3d ago", "Posted 5 hours ago"
+// or "30+ days ago".
+var relativeAgeRe = regexp.MustCompile(`(\d+)\+?\s*(minutes?|mins?|m|hours?|hrs?|h|days?|d|weeks?|w|months?|mo)\s+ago`)
+
+// parseRelativeAge converts a relative age label into an absolute time.
+func parseRelativeAge(text string, now time.Time) (time.Time, bool) {
+	lower := strings.ToLower(text)
+
+	switch {
+	case strings.Contains(lower, "just posted"), strings.Contains(lower, "today"), strings.Contains(lower, "just now"):
+		return now, true
+	case strings.Contains(lower, "yesterday"):
+		return now.Add(-24 * time.Hour), true
+	}
+
+	m := relativeAgeRe.FindStringSubmatch(lower)
+	if m == nil {
+		return time.Time{}, false
+	}
+	n, err := strconv.Atoi(m[1])
+	if err != nil {
+		return time.Time{}, false
+	}
+
+	var unit time.Duration
+	switch {
+	case strings.HasPrefix(m[2], "mo"):
+		unit = 30 * 24 * time.Hour
+	case strings.HasPrefix(m[2], "m"):
+		unit = time.Minute
+	case strings.HasPrefix(m[2], "h"):
+		unit = time.Hour
+	case strings.HasPrefix(m[2], "d"):
+		unit = 24 * time.Hour
+	case strings.HasPrefix(m[2], "w"):
+		unit = 7 * 24 * time.Hour
+	}
+	return now.Add(-time.Duration(n) * unit), true
+}
+
+// applicantsRe matches applicant counts such as "87 applicants", "Over 200
+// applicants" or "Be among the first 25 applicants".
+var applicantsRe = regexp.MustCompile(`(?i)(first\s+)?(\d[\d,]*)\s+applicants?`)
+
+// parseApplicants reads how many people have applied from a board's
+// applicant label. "Over 200" counts as 200 and "be among the first 25" as 0.
+func parseApplicants(text string) (int, bool) {
+	m := applicantsRe.FindStringSubmatch(text)
+	if m == nil {
+		return 0, false
+	}
+	if m[1] != "" {
+		return 0, true
+	}
+	n, err := strconv.Atoi(strings.ReplaceAll(m[2], ",", ""))
+	if err != nil {
+		return 0, false
+	}
+	return n, true
+}
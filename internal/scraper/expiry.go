@@ -0,0 +1,67 @@
+package scraper
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+
+	"github.com/gocolly/colly/v2"
+	"github.com/guidebee/jobseeker/internal/database"
+)
+
+// closedListingMarkers are phrases job boards show in place of a closed ad,
+// lower-cased. SEEK, LinkedIn and Indeed keep the job URL alive after the
+// job closes, so a 200 response alone doesn't mean the job is still open.
+var closedListingMarkers = [][]byte{
+	[]byte("this job is no longer advertised"),         // SEEK
+	[]byte("no longer accepting applications"),         // LinkedIn
+	[]byte("this job has expired"),                     // Indeed
+	[]byte("this job is no longer available"),          // Indeed, careers pages
+	[]byte("the job you are looking for is no longer"), // Greenhouse, Lever
+	[]byte("position has been filled"),
+}
+
+// ListingClosed visits a job's page to confirm that its listing has closed:
+// the page is gone (404 or 410) or says the job is no longer advertised.
+// Other failures are returned as errors, since they say nothing either way.
+func (s *Scraper) ListingClosed(job *database.Job) (bool, error) {
+	if job.URL == "" {
+		return false, fmt.Errorf("job %s has no URL", job.ExternalID)
+	}
+
+	c := s.newSiteCollector(&colly.LimitRule{
+		DomainGlob:  "*",
+		Delay:       s.delay,
+		Parallelism: 1,
+	})
+
+	var status int
+	var body []byte
+	var visitErr error
+	c.OnResponse(func(r *colly.Response) {
+		status, body = r.StatusCode, r.Body
+	})
+	c.OnError(func(r *colly.Response, err error) {
+		status, visitErr = r.StatusCode, err
+	})
+
+	if err := c.Visit(job.URL); err != nil {
+		return false, fmt.Errorf("failed to visit %s: %w", job.URL, err)
+	}
+	c.Wait()
+
+	switch {
+	case status == http.StatusNotFound || status == http.StatusGone:
+		return true, nil
+	case visitErr != nil:
+		return false, fmt.Errorf("%s returned %d: %w", job.URL, status, visitErr)
+	}
+
+	page := bytes.ToLower(body)
+	for _, marker := range closedListingMarkers {
+		if bytes.Contains(page, marker) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
@@ -0,0 +1,133 @@
+package scraper
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+
+	"github.com/guidebee/jobseeker/internal/database"
+)
+
+func TestListingClosed(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/job/open", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`<html><body><h1>Senior Go Engineer</h1><button>Apply</button></body></html>`)) //nolint:errcheck
+	})
+	mux.HandleFunc("/job/expired", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`<html><body><h2>This job is no longer advertised</h2></body></html>`)) //nolint:errcheck
+	})
+	mux.HandleFunc("/job/gone", func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "gone", http.StatusGone)
+	})
+	mux.HandleFunc("/job/down", func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "unavailable", http.StatusServiceUnavailable)
+	})
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	s := NewScraper(0)
+	for path, want := range map[string]bool{
+		"/job/open":    false,
+		"/job/expired": true,
+		"/job/gone":    true,
+		"/job/missing": true,
+	} {
+		closed, err := s.ListingClosed(&database.Job{URL: srv.URL + path})
+		if err != nil || closed != want {
+			t.Errorf("ListingClosed(%s) = %v, %v; want %v", path, closed, err, want)
+		}
+	}
+
+	if _, err := s.ListingClosed(&database.Job{URL: srv.URL + "/job/down"}); err == nil {
+		t.Error("expected an error for a server failure")
+	}
+}
+
+func TestSaveJobsDatabaseError(t *testing.T) {
+	if err := database.InitDB(filepath.Join(t.TempDir(), "closed.db")); err != nil {
+		t.Fatalf("InitDB failed: %v", err)
+	}
+	sqlDB, err := database.GetDB().DB()
+	if err != nil {
+		t.Fatalf("DB failed: %v", err)
+	}
+	sqlDB.Close()
+
+	jobs := []*database.Job{
+		{ExternalID: "seek-1", Source: "seek", Title: "Go Engineer", Status: "discovered"},
+		{ExternalID: "seek-2", Source: "seek", Title: "Platform Engineer", Status: "discovered"},
+	}
+	stats, err := SaveJobs(jobs, 1)
+	if err == nil {
+		t.Fatal("expected an error when the database is closed")
+	}
+	if stats.Failed != 2 || stats.New != 0 {
+		t.Errorf("stats = %+v, want 2 failed", stats)
+	}
+}
+
+func TestSaveJobsTracksChanges(t *testing.T) {
+	if err := database.InitDB(filepath.Join(t.TempDir(), "changes.db")); err != nil {
+		t.Fatalf("InitDB failed: %v", err)
+	}
+
+	scanned := func(salary string) []*database.Job {
+		return []*database.Job{{
+			ExternalID: "seek-81234567",
+			Source:     "seek",
+			URL:        "https://www.seek.com.au/job/81234567",
+			Title:      "Senior Go Engineer",
+			Company:    "Acme Payments",
+			Salary:     salary,
+			Status:     "discovered",
+		}}
+	}
+
+	if stats, err := SaveJobs(scanned("$900 per day"), 1); err != nil || stats.New != 1 {
+		t.Fatalf("first save = %+v, %v", stats, err)
+	}
+
+	var job database.Job
+	database.GetDB().Where("external_id = ?", "seek-81234567").First(&job)
+	if job.LastSeenAt == nil {
+		t.Error("LastSeenAt not set for a new job")
+	}
+	if err := database.MarkExpired(&job); err != nil {
+		t.Fatalf("MarkExpired failed: %v", err)
+	}
+
+	stats, err := SaveJobs(scanned("$1,000 per day"), 1)
+	if err != nil || stats.Duplicates != 1 || stats.Updated != 1 {
+		t.Fatalf("second save = %+v, %v", stats, err)
+	}
+
+	var refreshed database.Job
+	database.GetDB().First(&refreshed, job.ID)
+	if refreshed.Salary != "$1,000 per day" || refreshed.IsExpired() {
+		t.Errorf("job not refreshed: salary %q, expired %v", refreshed.Salary, refreshed.ExpiredAt)
+	}
+
+	revs, err := database.GetRevisions([]uint{job.ID})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []string
+	for _, rev := range revs[job.ID] {
+		got = append(got, rev.Field+":"+rev.OldValue+"→"+rev.NewValue)
+	}
+	want := []string{"listing:open→closed", "salary:$900 per day→$1,000 per day", "listing:closed→reposted"}
+	if len(got) != len(want) {
+		t.Fatalf("revisions = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("revision %d = %s, want %s", i, got[i], want[i])
+		}
+	}
+
+	// An unchanged re-scan records nothing
+	if stats, _ := SaveJobs(scanned("$1,000 per day"), 1); stats.Updated != 0 {
+		t.Errorf("unchanged job counted as updated: %+v", stats)
+	}
+}
@@ -0,0 +1,338 @@
+package scraper
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/guidebee/jobseeker/internal/database"
+	"github.com/guidebee/jobseeker/internal/profile"
+)
+
+func init() {
+	RegisterBoard("feeds", func(s *Scraper, _ profile.JobBoard) Board {
+		return &feedBoard{s: s}
+	})
+}
+
+// feedBoard reads RSS 2.0, RSS 1.0 (RDF), Atom and JSON Feed job feeds.
+// Feeds are structured data, so they keep working when a site's HTML changes.
+type feedBoard struct {
+	s *Scraper
+
+	// ids maps the link of each entry Search returned to its external ID,
+	// so that ExternalID gives the same ID as Search
+	mu  sync.Mutex
+	ids map[string]string
+}
+
+func (b *feedBoard) Name() string { return "feeds" }
+
+func (b *feedBoard) Search(target string) ([]*database.Job, error) {
+	body, err := b.s.getBody(target, "application/rss+xml, application/atom+xml, application/feed+json, application/xml;q=0.9, */*;q=0.8")
+	if err != nil {
+		return nil, fmt.Errorf("feeds: %w", err)
+	}
+
+	entries, err := parseFeed(body)
+	if err != nil {
+		return nil, fmt.Errorf("feeds: %s: %w", target, err)
+	}
+
+	var jobs []*database.Job
+	for _, e := range entries {
+		if e.Title == "" || e.Link == "" {
+			continue
+		}
+		id := feedExternalID(target, e.GUID, e.Link)
+		b.rememberID(e.Link, id)
+		job := &database.Job{
+			Source:       "feeds",
+			URL:          e.Link,
+			Title:        e.Title,
+			Company:      e.Author,
+			Description:  e.Summary,
+			Requirements: extractRequirements(e.Summary),
+			Status:       "discovered",
+			ExternalID:   id,
+			ListedAt:     e.Published,
+		}
+		job.JobType = database.DetectJobType(job.Title, job.Description, job.URL)
+		jobs = append(jobs, job)
+	}
+	return jobs, nil
+}
+
+// FetchDetail is a no-op: feed entries carry their own summary.
+func (b *feedBoard) FetchDetail(job *database.Job) error { return nil }
+
+// ExternalID returns the ID Search gave the entry linking to rawURL, which
+// is keyed by its feed and GUID. Links Search hasn't seen are keyed by the
+// link.
+func (b *feedBoard) ExternalID(rawURL string) string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if id, ok := b.ids[rawURL]; ok {
+		return id
+	}
+	return feedExternalID("", "", rawURL)
+}
+
+func (b *feedBoard) rememberID(link, id string) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.ids == nil {
+		b.ids = make(map[string]string)
+	}
+	b.ids[link] = id
+}
+
+// feedExternalID derives a stable ID from an entry's GUID, scoped to the
+// feed it came from since GUIDs like "123" or "?p=123" are only unique
+// within one feed, falling back to its link. GUIDs are often long URLs, so
+// they are hashed.
+func feedExternalID(feedURL, guid, link string) string {
+	key := strings.TrimSpace(link)
+	if guid = strings.TrimSpace(guid); guid != "" {
+		key = strings.TrimSpace(feedURL) + "\n" + guid
+	}
+	sum := sha256.Sum256([]byte(key))
+	return "feed-" + hex.EncodeToString(sum[:8])
+}
+
+// feedEntry is a feed item in a format-independent shape.
+type feedEntry struct {
+	GUID      string
+	Title     string
+	Link      string
+	Author    string
+	Summary   string // plain text
+	Published *time.Time
+}
+
+// parseFeed detects the feed format and returns its entries.
+func parseFeed(body []byte) ([]feedEntry, error) {
+	trimmed := bytes.TrimSpace(body)
+	if bytes.HasPrefix(trimmed, []byte("{")) {
+		return parseJSONFeed(trimmed)
+	}
+
+	var root struct {
+		XMLName xml.Name
+	}
+	if err := xml.Unmarshal(trimmed, &root); err != nil {
+		return nil, fmt.Errorf("not an RSS, Atom or JSON feed: %w", err)
+	}
+
+	switch root.XMLName.Local {
+	case "rss", "RDF":
+		return parseRSS(trimmed)
+	case "feed":
+		return parseAtom(trimmed)
+	default:
+		return nil, fmt.Errorf("unsupported feed root element <%s>", root.XMLName.Local)
+	}
+}
+
+type rssItem struct {
+	GUID        string `xml:"guid"`
+	Title       string `xml:"title"`
+	Link        string `xml:"link"`
+	Description string `xml:"description"`
+	Encoded     string `xml:"http://purl.org/rss/1.0/modules/content/ encoded"`
+	Author      string `xml:"author"`
+	Creator     string `xml:"http://purl.org/dc/elements/1.1/ creator"`
+	PubDate     string `xml:"pubDate"`
+	Date        string `xml:"http://purl.org/dc/elements/1.1/ date"`
+}
+
+func parseRSS(body []byte) ([]feedEntry, error) {
+	// RSS 2.0 nests items in <channel>; RSS 1.0 puts them beside it
+	var doc struct {
+		Channel struct {
+			Items []rssItem `xml:"item"`
+		} `xml:"channel"`
+		Items []rssItem `xml:"item"`
+	}
+	if err := xml.Unmarshal(body, &doc); err != nil {
+		return nil, fmt.Errorf("invalid RSS feed: %w", err)
+	}
+
+	var entries []feedEntry
+	for _, item := range append(doc.Channel.Items, doc.Items...) {
+		summary := item.Encoded
+		if strings.TrimSpace(summary) == "" {
+			summary = item.Description
+		}
+		author := item.Creator
+		if author == "" {
+			author = item.Author
+		}
+		entries = append(entries, feedEntry{
+			GUID:      item.GUID,
+			Title:     collapseSpace(item.Title),
+			Link:      strings.TrimSpace(item.Link),
+			Author:    collapseSpace(author),
+			Summary:   htmlToText(summary),
+			Published: parseFeedDate(item.PubDate, item.Date),
+		})
+	}
+	return entries, nil
+}
+
+// atomText is an Atom text construct; type="xhtml" content is inline markup.
+type atomText struct {
+	Type  string `xml:"type,attr"`
+	Text  string `xml:",chardata"`
+	Inner string `xml:",innerxml"`
+}
+
+func (t atomText) html() string {
+	if t.Type == "xhtml" {
+		return t.Inner
+	}
+	return t.Text
+}
+
+func parseAtom(body []byte) ([]feedEntry, error) {
+	var doc struct {
+		Entries []struct {
+			ID    string `xml:"id"`
+			Title string `xml:"title"`
+			Links []struct {
+				Href string `xml:"href,attr"`
+				Rel  string `xml:"rel,attr"`
+			} `xml:"link"`
+			Author struct {
+				Name string `xml:"name"`
+			} `xml:"author"`
+			Summary   atomText `xml:"summary"`
+			Content   atomText `xml:"content"`
+			Published string   `xml:"published"`
+			Updated   string   `xml:"updated"`
+		} `xml:"entry"`
+	}
+	if err := xml.Unmarshal(body, &doc); err != nil {
+		return nil, fmt.Errorf("invalid Atom feed: %w", err)
+	}
+
+	var entries []feedEntry
+	for _, e := range doc.Entries {
+		link := ""
+		for _, l := range e.Links {
+			if l.Rel == "" || l.Rel == "alternate" {
+				link = l.Href
+				break
+			}
+		}
+		summary := e.Content.html()
+		if strings.TrimSpace(summary) == "" {
+			summary = e.Summary.html()
+		}
+		entries = append(entries, feedEntry{
+			GUID:      e.ID,
+			Title:     collapseSpace(e.Title),
+			Link:      strings.TrimSpace(link),
+			Author:    collapseSpace(e.Author.Name),
+			Summary:   htmlToText(summary),
+			Published: parseFeedDate(e.Published, e.Updated),
+		})
+	}
+	return entries, nil
+}
+
+func parseJSONFeed(body []byte) ([]feedEntry, error) {
+	type jsonAuthor struct {
+		Name string `json:"name"`
+	}
+	var doc struct {
+		Version string `json:"version"`
+		Items   []struct {
+			ID            json.RawMessage `json:"id"` // a string, though some feeds emit numbers
+			URL           string          `json:"url"`
+			Title         string          `json:"title"`
+			ContentHTML   string          `json:"content_html"`
+			ContentText   string          `json:"content_text"`
+			Summary       string          `json:"summary"`
+			DatePublished string          `json:"date_published"`
+			DateModified  string          `json:"date_modified"`
+			Author        *jsonAuthor     `json:"author"`
+			Authors       []jsonAuthor    `json:"authors"`
+		} `json:"items"`
+	}
+	if err := json.Unmarshal(body, &doc); err != nil {
+		return nil, fmt.Errorf("invalid JSON feed: %w", err)
+	}
+	if !strings.HasPrefix(doc.Version, "https://jsonfeed.org/") {
+		return nil, fmt.Errorf("not a JSON Feed (version %q)", doc.Version)
+	}
+
+	var entries []feedEntry
+	for _, item := range doc.Items {
+		summary := strings.TrimSpace(item.ContentText)
+		if summary == "" {
+			summary = htmlToText(item.ContentHTML)
+		}
+		if summary == "" {
+			summary = strings.TrimSpace(item.Summary)
+		}
+		author := ""
+		if len(item.Authors) > 0 {
+			author = item.Authors[0].Name
+		} else if item.Author != nil {
+			author = item.Author.Name
+		}
+		entries = append(entries, feedEntry{
+			GUID:      jsonFeedID(item.ID),
+			Title:     collapseSpace(item.Title),
+			Link:      strings.TrimSpace(item.URL),
+			Author:    collapseSpace(author),
+			Summary:   summary,
+			Published: parseTimestamp(item.DatePublished, item.DateModified),
+		})
+	}
+	return entries, nil
+}
+
+// jsonFeedID returns a JSON Feed item's id, which should be a string but is
+// sometimes a number, and "" when it is null or missing.
+func jsonFeedID(raw json.RawMessage) string {
+	var id string
+	if err := json.Unmarshal(raw, &id); err == nil {
+		return id
+	}
+	var n json.Number
+	if err := json.Unmarshal(raw, &n); err == nil {
+		return n.String()
+	}
+	return ""
+}
+
+// feedDateLayouts are the date formats seen in RSS pubDate and dc:date.
+var feedDateLayouts = []string{
+	time.RFC1123Z,
+	time.RFC1123,
+	"Mon, 2 Jan 2006 15:04:05 -0700",
+	"Mon, 2 Jan 2006 15:04:05 MST",
+	"2 Jan 2006 15:04:05 -0700",
+	time.RFC3339,
+}
+
+// parseFeedDate returns the first candidate that parses as a feed date.
+func parseFeedDate(candidates ...string) *time.Time {
+	for _, c := range candidates {
+		c = strings.TrimSpace(c)
+		for _, layout := range feedDateLayouts {
+			if t, err := time.Parse(layout, c); err == nil {
+				return &t
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,158 @@
+package scraper
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestFeedBoard(t *testing.T) {
+	srv := httptest.NewServer(http.FileServer(http.Dir("testdata")))
+	t.Cleanup(srv.Close)
+	b := &feedBoard{s: NewScraper(0)}
+
+	tests := []struct {
+		fixture  string
+		title    string
+		company  string
+		jobType  string
+		summary  string
+		guid     string
+		listedAt string
+	}{
+		{
+			fixture:  "feed_rss.xml",
+			title:    "EL1 Data Engineer - Fixed Term Contract",
+			company:  "Australian Bureau of Statistics",
+			jobType:  "contract",
+			summary:  "Canberra or Melbourne.",
+			guid:     "APS-2025-1187",
+			listedAt: "2025-03-03T22:30:00Z",
+		},
+		{
+			fixture:  "feed_atom.xml",
+			title:    "Permanent Full Stack Developer",
+			company:  "Example Health",
+			jobType:  "permanent",
+			summary:  "React and Go across our patient apps.",
+			guid:     "tag:jobs.example.org,2025:role-88",
+			listedAt: "2025-03-05T07:45:00Z",
+		},
+		{
+			fixture:  "feed_json.json",
+			title:    "ML Platform Engineer",
+			company:  "Example AI",
+			jobType:  "contract", // "per day" in the summary
+			summary:  "Own our training platform.",
+			guid:     "https://remote.example.com/jobs/ml-platform-123",
+			listedAt: "2025-02-28T02:00:00Z",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.fixture, func(t *testing.T) {
+			jobs, err := b.Search(srv.URL + "/" + tt.fixture)
+			if err != nil {
+				t.Fatalf("Search failed: %v", err)
+			}
+			if len(jobs) != 1 {
+				t.Fatalf("expected 1 job, got %d", len(jobs))
+			}
+
+			job := jobs[0]
+			if job.Source != "feeds" || job.Title != tt.title || job.Company != tt.company {
+				t.Errorf("unexpected job: %s / %q / %q", job.Source, job.Title, job.Company)
+			}
+			if job.JobType != tt.jobType {
+				t.Errorf("job type = %q, want %q", job.JobType, tt.jobType)
+			}
+			if !strings.Contains(job.Description, tt.summary) {
+				t.Errorf("description = %q", job.Description)
+			}
+			if job.ExternalID != feedExternalID(srv.URL+"/"+tt.fixture, tt.guid, "") {
+				t.Errorf("external id = %s, want the GUID hash", job.ExternalID)
+			}
+			if got := b.ExternalID(job.URL); got != job.ExternalID {
+				t.Errorf("ExternalID(url) = %q, want %q", got, job.ExternalID)
+			}
+			if job.ListedAt == nil || job.ListedAt.UTC().Format(time.RFC3339) != tt.listedAt {
+				t.Errorf("listed at = %v, want %s", job.ListedAt, tt.listedAt)
+			}
+		})
+	}
+}
+
+func TestFeedRequirements(t *testing.T) {
+	entries, err := parseFeed([]byte(`<rss version="2.0"><channel><item>
+		<title>Analyst</title><link>https://example.org/1</link>
+		<description>&lt;h4&gt;Selection criteria&lt;/h4&gt;&lt;ul&gt;&lt;li&gt;SQL&lt;/li&gt;&lt;/ul&gt;</description>
+	</item></channel></rss>`))
+	if err != nil || len(entries) != 1 {
+		t.Fatalf("parseFeed = %v, %v", entries, err)
+	}
+	if got := extractRequirements(entries[0].Summary); got != "SQL" {
+		t.Errorf("requirements = %q", got)
+	}
+}
+
+func TestFeedExternalIDStable(t *testing.T) {
+	const feed = "https://example.org/jobs.rss"
+	a := feedExternalID(feed, "APS-2025-1187", "https://example.org/a")
+	b := feedExternalID(feed, "APS-2025-1187", "https://example.org/b?utm_source=rss")
+	if a != b {
+		t.Errorf("same GUID gave different IDs: %s %s", a, b)
+	}
+	if feedExternalID(feed, "", "https://example.org/a") == a {
+		t.Error("link fallback should not collide with the GUID")
+	}
+}
+
+func TestFeedExternalIDScopedToFeed(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`<rss version="2.0"><channel><item><guid isPermaLink="false">123</guid>` + //nolint:errcheck
+			`<title>Analyst</title><link>https://example.org` + r.URL.Path + `/123</link></item></channel></rss>`))
+	}))
+	t.Cleanup(srv.Close)
+	b := &feedBoard{s: NewScraper(0)}
+
+	// Two feeds numbering their entries from 1 both have a GUID "123"
+	var ids []string
+	for _, feed := range []string{"/health", "/mining"} {
+		jobs, err := b.Search(srv.URL + feed)
+		if err != nil || len(jobs) != 1 {
+			t.Fatalf("Search(%s) = %d jobs, %v", feed, len(jobs), err)
+		}
+		if jobs[0].ExternalID != feedExternalID(srv.URL+feed, "123", "") {
+			t.Errorf("external id of %s = %s, want its GUID hash", feed, jobs[0].ExternalID)
+		}
+		ids = append(ids, jobs[0].ExternalID)
+	}
+	if ids[0] == ids[1] {
+		t.Errorf("entries of two feeds sharing a GUID got the same ID %s", ids[0])
+	}
+}
+
+func TestJSONFeedNullID(t *testing.T) {
+	entries, err := parseFeed([]byte(`{"version": "https://jsonfeed.org/version/1.1", "items": [
+		{"id": null, "url": "https://example.org/a", "title": "A"},
+		{"id": null, "url": "https://example.org/b", "title": "B"},
+		{"id": 42, "url": "https://example.org/c", "title": "C"}]}`))
+	if err != nil || len(entries) != 3 {
+		t.Fatalf("parseFeed = %v, %v", entries, err)
+	}
+	if entries[0].GUID != "" || entries[1].GUID != "" || entries[2].GUID != "42" {
+		t.Errorf("GUIDs = %q, %q, %q; want none for null ids", entries[0].GUID, entries[1].GUID, entries[2].GUID)
+	}
+	feed := "https://example.org/feed.json"
+	if feedExternalID(feed, entries[0].GUID, entries[0].Link) == feedExternalID(feed, entries[1].GUID, entries[1].Link) {
+		t.Error("items with null ids share an ID")
+	}
+}
+
+func TestParseFeedRejectsHTML(t *testing.T) {
+	if _, err := parseFeed([]byte("<html><body>Not a feed</body></html>")); err == nil {
+		t.Error("expected an error for an HTML page")
+	}
+}
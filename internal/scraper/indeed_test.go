@@ -1,3 +1,5 @@
+//go:build live
+
 package scraper
 
 import (
@@ -6,7 +8,7 @@ import (
 
 // TestScrapeIndeed tests the Indeed scraper
 // This is a live test that actually scrapes Indeed
-// Run with: go test ./internal/scraper -run TestScrapeIndeed -v
+// Run with: go test -tags live ./internal/scraper -run TestScrapeIndeed -v
 func TestScrapeIndeed(t *testing.T) {
 	// Create scraper with 3 second delay
 	s := NewScraper(3000)
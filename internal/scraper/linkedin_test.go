@@ -1,3 +1,5 @@
+//go:build live
+
 package scraper
 
 import (
@@ -6,7 +8,7 @@ import (
 
 // TestScrapeLinkedIn tests the LinkedIn scraper
 // This is a live test that actually scrapes LinkedIn
-// Run with: go test ./internal/scraper -run TestScrapeLinkedIn -v
+// Run with: go test -tags live ./internal/scraper -run TestScrapeLinkedIn -v
 func TestScrapeLinkedIn(t *testing.T) {
 	// Create scraper with 3 second delay
 	s := NewScraper(3000)
@@ -0,0 +1,94 @@
+package scraper
+
+import (
+	"log"
+	"net/url"
+
+	"github.com/guidebee/jobseeker/internal/database"
+)
+
+// Pagination limits how far a search follows its result pages.
+type Pagination struct {
+	MaxPages int // Result pages to visit per search URL (default 1)
+	MaxJobs  int // Stop once this many jobs were collected (0 = no limit)
+}
+
+// pageLimit returns the number of pages to visit, defaulting to one.
+func (p Pagination) pageLimit() int {
+	if p.MaxPages < 1 {
+		return 1
+	}
+	return p.MaxPages
+}
+
+// paginate visits successive result pages until a limit is reached, a page
+// yields no new jobs, or every job on a page is already stored for the user.
+//
+// pageURL builds the URL for a zero-based page index; offset is the number of
+// results returned by the previous pages, for boards that page by offset.
+func (s *Scraper) paginate(p Pagination, pageURL func(page, offset int) string, scrapePage func(string) ([]*database.Job, error)) ([]*database.Job, error) {
+	var all []*database.Job
+	seen := make(map[string]bool) // promoted cards often repeat on every page
+	offset := 0
+
+	for page := 0; page < p.pageLimit(); page++ {
+		jobs, err := scrapePage(pageURL(page, offset))
+		if err != nil {
+			if page == 0 {
+				return nil, err
+			}
+			log.Printf("Stopping pagination after page %d: %v", page, err)
+			break
+		}
+		offset += len(jobs)
+
+		added, known := 0, 0
+		for _, job := range jobs {
+			if seen[job.ExternalID] {
+				continue
+			}
+			seen[job.ExternalID] = true
+			all = append(all, job)
+			added++
+			if s.isKnown(job.ExternalID) {
+				known++
+			}
+			if p.MaxJobs > 0 && len(all) >= p.MaxJobs {
+				return all, nil
+			}
+		}
+
+		if added == 0 {
+			break // ran past the last page of results
+		}
+		if known == added && page+1 < p.pageLimit() {
+			log.Printf("Page %d contains only known jobs, not following further pages", page+1)
+			break
+		}
+	}
+
+	return all, nil
+}
+
+// firstPageExpects adapts a page scraper for paginate. Only the first page
+// is checked against the search's usual yield: later pages legitimately run
+// out of results.
+func firstPageExpects(expected int, scrapePage func(pageURL string, expected int) ([]*database.Job, error)) func(string) ([]*database.Job, error) {
+	return func(pageURL string) ([]*database.Job, error) {
+		jobs, err := scrapePage(pageURL, expected)
+		expected = 0
+		return jobs, err
+	}
+}
+
+// withQueryParam returns rawURL with a single query parameter set.
+func withQueryParam(rawURL, key, value string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return rawURL
+	}
+	q := u.Query()
+	q.Set(key, value)
+	u.RawQuery = q.Encode()
+	return u.String()
+}
@@ -0,0 +1,77 @@
+package scraper
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/guidebee/jobseeker/internal/database"
+)
+
+// fakePages serves canned result pages keyed by page URL.
+func fakePages(pages map[string][]string) func(string) ([]*database.Job, error) {
+	return func(pageURL string) ([]*database.Job, error) {
+		var jobs []*database.Job
+		for _, id := range pages[pageURL] {
+			jobs = append(jobs, &database.Job{ExternalID: id, Title: id})
+		}
+		return jobs, nil
+	}
+}
+
+func pageURL(page, _ int) string { return fmt.Sprintf("page-%d", page) }
+
+func TestPaginateFollowsPagesUntilEmpty(t *testing.T) {
+	s := NewScraper(0)
+	scrape := fakePages(map[string][]string{
+		"page-0": {"a", "b"},
+		"page-1": {"b", "c"}, // "b" is a promoted card repeated across pages
+	})
+
+	jobs, err := s.paginate(Pagination{MaxPages: 5}, pageURL, scrape)
+	if err != nil {
+		t.Fatalf("paginate failed: %v", err)
+	}
+	if len(jobs) != 3 {
+		t.Fatalf("expected 3 unique jobs, got %d", len(jobs))
+	}
+}
+
+func TestPaginateRespectsMaxJobs(t *testing.T) {
+	s := NewScraper(0)
+	scrape := fakePages(map[string][]string{
+		"page-0": {"a", "b"},
+		"page-1": {"c", "d"},
+	})
+
+	jobs, _ := s.paginate(Pagination{MaxPages: 5, MaxJobs: 3}, pageURL, scrape)
+	if len(jobs) != 3 {
+		t.Fatalf("expected MaxJobs to cap results at 3, got %d", len(jobs))
+	}
+}
+
+func TestPaginateStopsOnKnownPage(t *testing.T) {
+	s := NewScraper(0)
+	s.SetKnownJobs(func(id string) bool { return id == "a" || id == "b" })
+
+	visited := 0
+	scrape := func(pageURL string) ([]*database.Job, error) {
+		visited++
+		return fakePages(map[string][]string{
+			"page-0": {"a", "b"},
+			"page-1": {"c"},
+		})(pageURL)
+	}
+
+	jobs, _ := s.paginate(Pagination{MaxPages: 5}, pageURL, scrape)
+	if visited != 1 || len(jobs) != 2 {
+		t.Fatalf("expected to stop after the first known-only page, visited %d pages", visited)
+	}
+}
+
+func TestWithQueryParam(t *testing.T) {
+	got := withQueryParam("https://www.seek.com.au/jobs?keywords=go", "page", "2")
+	want := "https://www.seek.com.au/jobs?keywords=go&page=2"
+	if got != want {
+		t.Errorf("withQueryParam = %q, want %q", got, want)
+	}
+}
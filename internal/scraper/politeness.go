@@ -0,0 +1,248 @@
+package scraper
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/guidebee/jobseeker/internal/profile"
+	"github.com/guidebee/jobseeker/pkg/apierror"
+	"github.com/temoto/robotstxt"
+)
+
+// ErrDisallowed means a site's robots.txt disallows a URL. Match it with
+// errors.Is.
+var ErrDisallowed = errors.New("disallowed by robots.txt")
+
+// ErrSitePaused means a site blocked so many requests in a row that it is
+// left alone for the rest of the run. Match it with errors.Is.
+var ErrSitePaused = errors.New("site paused after repeated blocks")
+
+// maxRetryWait is the longest a blocked request waits before its retry. A
+// site asking for a longer Retry-After is treated as blocking.
+const maxRetryWait = 2 * time.Minute
+
+// retryBackoff is the wait before the first retry of a blocked response
+// without a Retry-After header; it doubles with every further retry.
+const retryBackoff = 5 * time.Second
+
+// WithPoliteness applies per-site politeness to every request: a request
+// rate cap, robots.txt, retries of 429, 403 and 503 responses after their
+// Retry-After, and pausing a site that keeps blocking (see ErrSitePaused).
+func WithPoliteness(p profile.Politeness) Option {
+	return func(s *Scraper) {
+		s.politeness = &p
+	}
+}
+
+// politeTransport enforces a profile.Politeness on the requests it passes to
+// next. Its state lasts as long as the Scraper, i.e. one run.
+type politeTransport struct {
+	policy func(host string) profile.SitePolicy
+	next   http.RoundTripper
+	now    func() time.Time
+	sleep  func(ctx context.Context, d time.Duration) error
+
+	mu    sync.Mutex
+	sites map[string]*siteState
+}
+
+// siteState is what politeTransport tracks for one host.
+type siteState struct {
+	policy profile.SitePolicy
+
+	mu      sync.Mutex
+	next    time.Time // earliest time the next request may start
+	blocked int       // requests in a row that ended blocked
+	paused  bool
+
+	robotsOnce sync.Once
+	robots     *robotstxt.RobotsData // nil if robots.txt is ignored or unreadable
+}
+
+func newPoliteTransport(p profile.Politeness, next http.RoundTripper) *politeTransport {
+	return &politeTransport{
+		policy: p.For,
+		next:   next,
+		now:    time.Now,
+		sleep:  sleepContext,
+		sites:  make(map[string]*siteState),
+	}
+}
+
+func sleepContext(ctx context.Context, d time.Duration) error {
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-t.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (t *politeTransport) site(host string) *siteState {
+	host = strings.ToLower(host)
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	site, ok := t.sites[host]
+	if !ok {
+		site = &siteState{policy: t.policy(host)}
+		t.sites[host] = site
+	}
+	return site
+}
+
+func (t *politeTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	site := t.site(req.URL.Hostname())
+
+	site.mu.Lock()
+	paused := site.paused
+	site.mu.Unlock()
+	if paused {
+		return nil, fmt.Errorf("%s: %w", req.URL.Host, ErrSitePaused)
+	}
+
+	if site.policy.RespectRobots && req.URL.Path != "/robots.txt" {
+		site.robotsOnce.Do(func() { site.robots = t.fetchRobots(req) })
+		if site.robots != nil && !site.robots.TestAgent(req.URL.Path, req.UserAgent()) {
+			return nil, fmt.Errorf("%s: %w", req.URL, ErrDisallowed)
+		}
+	}
+
+	retryable := req.Method == http.MethodGet || req.Method == http.MethodHead
+	for attempt := 0; ; attempt++ {
+		if err := t.wait(req.Context(), site); err != nil {
+			return nil, err
+		}
+		resp, err := t.next.RoundTrip(req)
+		if err != nil {
+			return nil, err
+		}
+		if !isBlocked(resp.StatusCode) {
+			site.mu.Lock()
+			site.blocked = 0
+			site.mu.Unlock()
+			return resp, nil
+		}
+
+		wait, ok := retryWait(resp, attempt, t.now())
+		if !retryable || attempt >= site.policy.MaxRetries || !ok {
+			t.recordBlock(site, req.URL.Host, resp.StatusCode)
+			return resp, nil
+		}
+		log.Printf("%s returned %d, retrying in %s", req.URL, resp.StatusCode, wait.Round(time.Second))
+		io.Copy(io.Discard, resp.Body) //nolint:errcheck
+		resp.Body.Close()
+		if err := t.sleep(req.Context(), wait); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// wait holds a request back until the host's request rate allows it.
+func (t *politeTransport) wait(ctx context.Context, site *siteState) error {
+	if site.policy.RequestsPerMinute <= 0 {
+		return nil
+	}
+	interval := time.Minute / time.Duration(site.policy.RequestsPerMinute)
+
+	site.mu.Lock()
+	now := t.now()
+	start := site.next
+	if start.Before(now) {
+		start = now
+	}
+	site.next = start.Add(interval)
+	site.mu.Unlock()
+
+	if d := start.Sub(now); d > 0 {
+		return t.sleep(ctx, d)
+	}
+	return nil
+}
+
+// recordBlock counts a request that ended blocked, pausing the host once
+// its policy's threshold is reached.
+func (t *politeTransport) recordBlock(site *siteState, host string, status int) {
+	site.mu.Lock()
+	defer site.mu.Unlock()
+	site.blocked++
+	if site.policy.BlockThreshold > 0 && site.blocked >= site.policy.BlockThreshold && !site.paused {
+		site.paused = true
+		log.Printf("%s blocked %d requests in a row (last %d); pausing it for the rest of the run", host, site.blocked, status)
+	}
+}
+
+// fetchRobots reads the robots.txt of req's host. A robots.txt that can't be
+// fetched doesn't stop the scan.
+func (t *politeTransport) fetchRobots(req *http.Request) *robotstxt.RobotsData {
+	robotsURL := *req.URL
+	robotsURL.Path, robotsURL.RawPath, robotsURL.RawQuery, robotsURL.Fragment = "/robots.txt", "", "", ""
+
+	robotsReq, err := http.NewRequestWithContext(req.Context(), http.MethodGet, robotsURL.String(), nil)
+	if err != nil {
+		return nil
+	}
+	robotsReq.Header.Set("User-Agent", req.UserAgent())
+	resp, err := t.next.RoundTrip(robotsReq)
+	if err != nil {
+		log.Printf("Could not fetch %s, ignoring it: %v", robotsURL.String(), err)
+		return nil
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil
+	}
+	robots, err := robotstxt.FromStatusAndBytes(resp.StatusCode, body)
+	if err != nil {
+		log.Printf("Could not parse %s, ignoring it: %v", robotsURL.String(), err)
+		return nil
+	}
+	return robots
+}
+
+// isBlocked reports whether a status means the site is refusing or
+// throttling requests.
+func isBlocked(status int) bool {
+	return status == http.StatusTooManyRequests || status == http.StatusForbidden || status == http.StatusServiceUnavailable
+}
+
+// retryWait returns how long to wait before retrying a blocked response: its
+// Retry-After (in seconds or as a date) or an exponential backoff. It
+// reports false if the site asks for longer than maxRetryWait.
+func retryWait(resp *http.Response, attempt int, now time.Time) (time.Duration, bool) {
+	wait := retryBackoff << attempt
+	if ra, ok := apierror.ParseRetryAfter(resp.Header.Get("Retry-After"), now); ok {
+		wait = ra
+	}
+	return wait, wait <= maxRetryWait
+}
+
+// PausedSites returns the hosts paused after repeated blocks this run.
+func (s *Scraper) PausedSites() []string {
+	t, ok := s.transport.(*politeTransport)
+	if !ok {
+		return nil
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	var hosts []string
+	for host, site := range t.sites {
+		site.mu.Lock()
+		if site.paused {
+			hosts = append(hosts, host)
+		}
+		site.mu.Unlock()
+	}
+	sort.Strings(hosts)
+	return hosts
+}
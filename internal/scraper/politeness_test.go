@@ -0,0 +1,147 @@
+package scraper
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/guidebee/jobseeker/internal/profile"
+)
+
+// politeScraper returns a scraper with politeness p whose waits are recorded
+// in sleeps instead of slept.
+func politeScraper(p profile.Politeness, sleeps *[]time.Duration) *Scraper {
+	s := NewScraper(0, WithPoliteness(p))
+	s.transport.(*politeTransport).sleep = func(_ context.Context, d time.Duration) error {
+		*sleeps = append(*sleeps, d)
+		return nil
+	}
+	return s
+}
+
+func TestPolitenessRobots(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/robots.txt" {
+			w.Write([]byte("User-agent: *\nDisallow: /private\n")) //nolint:errcheck
+			return
+		}
+		w.Write([]byte(`{}`)) //nolint:errcheck
+	}))
+	defer srv.Close()
+
+	var sleeps []time.Duration
+	s := politeScraper(profile.Politeness{}, &sleeps)
+	if _, err := s.getBody(srv.URL+"/jobs", "application/json"); err != nil {
+		t.Fatalf("allowed URL failed: %v", err)
+	}
+	if _, err := s.getBody(srv.URL+"/private/jobs", "application/json"); !errors.Is(err, ErrDisallowed) {
+		t.Errorf("disallowed URL returned %v, want ErrDisallowed", err)
+	}
+
+	off := false
+	s = politeScraper(profile.Politeness{Default: profile.DomainPolicy{RespectRobots: &off}}, &sleeps)
+	if _, err := s.getBody(srv.URL+"/private/jobs", "application/json"); err != nil {
+		t.Errorf("robots.txt applied with respect_robots off: %v", err)
+	}
+}
+
+func TestPolitenessRetryAfter(t *testing.T) {
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/robots.txt" && calls.Add(1) == 1 {
+			w.Header().Set("Retry-After", "7")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.Write([]byte(`{}`)) //nolint:errcheck
+	}))
+	defer srv.Close()
+
+	var sleeps []time.Duration
+	s := politeScraper(profile.Politeness{}, &sleeps)
+	if _, err := s.getBody(srv.URL+"/jobs", "application/json"); err != nil {
+		t.Fatalf("getBody failed after a retry: %v", err)
+	}
+	if !slices.Equal(sleeps, []time.Duration{7 * time.Second}) {
+		t.Errorf("waited %v, want the 7s Retry-After", sleeps)
+	}
+}
+
+func TestPolitenessPausesBlockingSite(t *testing.T) {
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/robots.txt" {
+			calls.Add(1)
+		}
+		w.WriteHeader(http.StatusForbidden)
+	}))
+	defer srv.Close()
+
+	var sleeps []time.Duration
+	s := politeScraper(profile.Politeness{Default: profile.DomainPolicy{MaxRetries: 1, BlockThreshold: 2}}, &sleeps)
+	for i := 0; i < 2; i++ {
+		if _, err := s.getBody(srv.URL+"/jobs", "text/html"); err == nil || errors.Is(err, ErrSitePaused) {
+			t.Fatalf("request %d: got %v, want the 403", i+1, err)
+		}
+	}
+	if _, err := s.getBody(srv.URL+"/jobs", "text/html"); !errors.Is(err, ErrSitePaused) {
+		t.Errorf("third request returned %v, want ErrSitePaused", err)
+	}
+	// Two requests with one retry each; the paused third never went out
+	if calls.Load() != 4 {
+		t.Errorf("site was called %d times, want 4", calls.Load())
+	}
+	if !slices.Equal(sleeps, []time.Duration{retryBackoff, retryBackoff}) {
+		t.Errorf("waited %v before retrying", sleeps)
+	}
+	if paused := s.PausedSites(); len(paused) != 1 {
+		t.Errorf("PausedSites = %v, want the test server", paused)
+	}
+}
+
+func TestPolitenessRequestsPerMinute(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{}`)) //nolint:errcheck
+	}))
+	defer srv.Close()
+
+	var sleeps []time.Duration
+	s := politeScraper(profile.Politeness{Default: profile.DomainPolicy{RequestsPerMinute: 20}}, &sleeps)
+	now := time.Date(2025, 3, 10, 9, 0, 0, 0, time.UTC)
+	s.transport.(*politeTransport).now = func() time.Time { return now }
+
+	for i := 0; i < 3; i++ {
+		if _, err := s.getBody(srv.URL+"/jobs", "application/json"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if !slices.Equal(sleeps, []time.Duration{3 * time.Second, 6 * time.Second}) {
+		t.Errorf("waited %v, want 3s then 6s at 20 requests a minute", sleeps)
+	}
+}
+
+func TestRetryWait(t *testing.T) {
+	now := time.Date(2025, 3, 10, 9, 0, 0, 0, time.UTC)
+	resp := func(retryAfter string) *http.Response {
+		r := &http.Response{Header: http.Header{}}
+		if retryAfter != "" {
+			r.Header.Set("Retry-After", retryAfter)
+		}
+		return r
+	}
+
+	if d, ok := retryWait(resp(""), 2, now); !ok || d != 4*retryBackoff {
+		t.Errorf("backoff = %v, %v", d, ok)
+	}
+	if d, ok := retryWait(resp(now.Add(30*time.Second).Format(http.TimeFormat)), 0, now); !ok || d != 30*time.Second {
+		t.Errorf("Retry-After date = %v, %v", d, ok)
+	}
+	if _, ok := retryWait(resp("3600"), 0, now); ok {
+		t.Error("an hour's Retry-After should not be waited for")
+	}
+}
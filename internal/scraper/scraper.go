@@ -3,16 +3,18 @@ package scraper
 import (
 	"errors"
 	"fmt"
-	"io"
 	"log"
 	"net/http"
 	"net/url"
+	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/PuerkitoBio/goquery"
 	"github.com/gocolly/colly/v2"
 	"github.com/guidebee/jobseeker/internal/database"
+	"github.com/guidebee/jobseeker/internal/profile"
 	"gorm.io/gorm"
 )
 
@@ -20,16 +22,115 @@ import (
 type Scraper struct {
 	delay      time.Duration
 	httpClient *http.Client
+	transport  http.RoundTripper // nil means the default transport
+
+	// known reports whether a job is already stored for the current user.
+	// Pagination stops once a page holds nothing but known jobs.
+	known func(externalID string) bool
+
+	// bases holds one collector per site; newCollector hands out clones of it
+	basesMu sync.Mutex
+	bases   map[string]*siteBase
+
+	// snapshotDir receives copies of pages whose selectors broke
+	snapshotDir string
+	// expected reports how many jobs a search usually finds (see SetExpectedYield)
+	expected func(board, target string) int
+
+	// now is the clock relative ages such as "3 days ago" are read against
+	now func() time.Time
+
+	// politeness, if set, wraps transport in a politeTransport
+	politeness *profile.Politeness
+}
+
+// siteBase is the collector whose HTTP backend every collector for a site shares.
+type siteBase struct {
+	c       *colly.Collector
+	limited bool // a LimitRule has been installed
+}
+
+// Option configures a Scraper.
+type Option func(*Scraper)
+
+// WithBaseURL sends every request, whatever site it is for, to baseURL
+// instead. Paths and queries are kept and the original host is passed in the
+// Host header, so a single test server can stand in for all job boards while
+// scraped job URLs still point at the real sites.
+func WithBaseURL(baseURL string) Option {
+	return func(s *Scraper) {
+		s.transport = &rewriteTransport{base: baseURL, next: http.DefaultTransport}
+	}
+}
+
+// WithClock reads relative listing ages ("Posted 3 days ago") against now
+// instead of the system clock, so parsed dates are reproducible in tests.
+func WithClock(now func() time.Time) Option {
+	return func(s *Scraper) {
+		s.now = now
+	}
 }
 
 // NewScraper creates a new scraper instance
-func NewScraper(delayMs int) *Scraper {
-	return &Scraper{
+func NewScraper(delayMs int, opts ...Option) *Scraper {
+	s := &Scraper{
 		delay: time.Duration(delayMs) * time.Millisecond,
 		httpClient: &http.Client{
 			Timeout: 30 * time.Second,
 		},
+		bases: make(map[string]*siteBase),
+		now:   time.Now,
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	if s.politeness != nil {
+		next := s.transport
+		if next == nil {
+			next = http.DefaultTransport
+		}
+		s.transport = newPoliteTransport(*s.politeness, next)
+	}
+	if s.transport != nil {
+		s.httpClient.Transport = s.transport
+	}
+	return s
+}
+
+// rewriteTransport redirects requests to another server (see WithBaseURL).
+type rewriteTransport struct {
+	base string
+	next http.RoundTripper
+}
+
+func (t *rewriteTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	base, err := url.Parse(t.base)
+	if err != nil {
+		return nil, fmt.Errorf("invalid base URL %q: %w", t.base, err)
+	}
+
+	out := req.Clone(req.Context())
+	if out.Host == "" {
+		out.Host = req.URL.Host
+	}
+	out.URL.Scheme = base.Scheme
+	out.URL.Host = base.Host
+	resp, err := t.next.RoundTrip(out)
+	if resp != nil {
+		resp.Request = req // colly resolves links against the response's request URL
 	}
+	return resp, err
+}
+
+// SetKnownJobs installs the lookup used to stop pagination early once a
+// result page contains only jobs that are already stored.
+func (s *Scraper) SetKnownJobs(known func(externalID string) bool) {
+	s.known = known
+}
+
+// isKnown reports whether a job is already stored for the current user.
+func (s *Scraper) isKnown(externalID string) bool {
+	return s.known != nil && s.known(externalID)
 }
 
 const browserUA = "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/134.0.0.0 Safari/537.36"
@@ -38,11 +139,37 @@ const browserUA = "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36
 // Each Scrape* method creates its own collector so OnHTML handlers never
 // accumulate across multiple calls.
 func (s *Scraper) newCollector(domains ...string) *colly.Collector {
-	c := colly.NewCollector(
-		colly.AllowedDomains(domains...),
-		colly.Async(true),
-		colly.UserAgent(browserUA),
-	)
+	return s.newSiteCollector(nil, domains...)
+}
+
+// newSiteCollector is newCollector for a site with a LimitRule. Collectors
+// for the same domains are clones of one base collector and share its HTTP
+// backend, so the first rule given for a site throttles every request to it,
+// even when several scans run at once.
+func (s *Scraper) newSiteCollector(rule *colly.LimitRule, domains ...string) *colly.Collector {
+	key := strings.Join(domains, ",")
+
+	s.basesMu.Lock()
+	base, ok := s.bases[key]
+	if !ok {
+		base = &siteBase{c: colly.NewCollector(
+			colly.AllowedDomains(domains...),
+			colly.Async(true),
+			colly.UserAgent(browserUA),
+			colly.AllowURLRevisit(), // clones share the visited-URL store too
+		)}
+		if s.transport != nil {
+			base.c.WithTransport(s.transport)
+		}
+		s.bases[key] = base
+	}
+	if rule != nil && !base.limited {
+		base.c.Limit(rule) //nolint:errcheck
+		base.limited = true
+	}
+	s.basesMu.Unlock()
+
+	c := base.c.Clone()
 
 	c.OnRequest(func(r *colly.Request) {
 		log.Printf("Visiting: %s", r.URL)
@@ -84,18 +211,64 @@ func (s *Scraper) newCollector(domains ...string) *colly.Collector {
 	return c
 }
 
-// ScrapeSeek scrapes job listings from SEEK
-func (s *Scraper) ScrapeSeek(searchURL string) ([]*database.Job, error) {
-	var jobs []*database.Job
-	seen := make(map[string]bool) // dedup within a single page (promoted vs standard cards)
-
-	c := s.newCollector("seek.com.au", "www.seek.com.au")
-	c.Limit(&colly.LimitRule{ //nolint:errcheck
+// newSeekCollector creates a collector for SEEK pages. Search and detail
+// fetches share the same LimitRule so both stay equally polite.
+func (s *Scraper) newSeekCollector() *colly.Collector {
+	return s.newSiteCollector(&colly.LimitRule{
 		DomainGlob:  "*seek.com.au*",
 		Delay:       s.delay,
 		RandomDelay: 1 * time.Second,
 		Parallelism: 2,
+	}, "seek.com.au", "www.seek.com.au")
+}
+
+// newLinkedInCollector creates a collector for LinkedIn's guest APIs. Job
+// list and description fetches share the same LimitRule.
+func (s *Scraper) newLinkedInCollector() *colly.Collector {
+	c := s.newSiteCollector(&colly.LimitRule{
+		DomainGlob:  "*linkedin.com*",
+		Delay:       s.delay,
+		RandomDelay: 2 * time.Second,
+		Parallelism: 1,
+	}, "www.linkedin.com", "linkedin.com", "au.linkedin.com")
+
+	// Add LinkedIn-specific headers (OnRequest from newCollector already logs the URL)
+	c.OnRequest(func(r *colly.Request) {
+		r.Headers.Set("Referer", "https://www.linkedin.com/jobs/search/")
+		r.Headers.Set("Accept", "text/html,application/xhtml+xml,*/*;q=0.8")
+		r.Headers.Set("Accept-Language", "en-AU,en;q=0.9")
 	})
+	return c
+}
+
+// ScrapeSeek scrapes job listings from the first page of a SEEK search
+func (s *Scraper) ScrapeSeek(searchURL string) ([]*database.Job, error) {
+	return s.scrapeSeekPage(searchURL, s.expectedYield("seek", searchURL))
+}
+
+// ScrapeSeekPages scrapes a SEEK search, following page=N result pages.
+func (s *Scraper) ScrapeSeekPages(searchURL string, p Pagination) ([]*database.Job, error) {
+	return s.paginate(p, func(page, _ int) string {
+		if page == 0 {
+			return searchURL
+		}
+		return withQueryParam(searchURL, "page", strconv.Itoa(page+1))
+	}, firstPageExpects(s.expectedYield("seek", searchURL), s.scrapeSeekPage))
+}
+
+// seekResultsContainer is present on every SEEK results page, including one
+// with no matches.
+const seekResultsContainer = "[data-automation='totalJobsCount'], [data-automation='searchZeroResults']"
+
+// scrapeSeekPage scrapes a single SEEK search result page. expected is how
+// many jobs the page usually holds, 0 if unknown.
+func (s *Scraper) scrapeSeekPage(pageURL string, expected int) ([]*database.Job, error) {
+	var jobs []*database.Job
+	seen := make(map[string]bool) // dedup within a single page (promoted vs standard cards)
+
+	c := s.newSeekCollector()
+	probe := &pageProbe{}
+	probe.watch(c, seekResultsContainer)
 
 	c.OnHTML("article[data-testid='job-card']", func(e *colly.HTMLElement) {
 		title := ""
@@ -163,11 +336,14 @@ func (s *Scraper) ScrapeSeek(searchURL string) ([]*database.Job, error) {
 		}
 	})
 
-	if err := c.Visit(searchURL); err != nil {
+	if err := c.Visit(pageURL); err != nil {
 		return nil, fmt.Errorf("failed to visit URL: %w", err)
 	}
 	c.Wait()
 
+	if err := s.checkResultsPage("seek", pageURL, len(jobs), probe, expected); err != nil {
+		return nil, err
+	}
 	return jobs, nil
 }
 
@@ -180,48 +356,54 @@ func (s *Scraper) ScrapeLinkedIn(searchURL string) ([]*database.Job, error) {
 	apiURL := linkedInSearchToAPI(searchURL)
 	log.Printf("LinkedIn: fetching job list from %s", apiURL)
 
-	jobs, err := s.fetchLinkedInJobList(apiURL)
+	jobs, err := s.fetchLinkedInJobList(apiURL, s.expectedYield("linkedin", searchURL))
 	if err != nil {
 		return nil, err
 	}
 	log.Printf("LinkedIn: found %d jobs, fetching descriptions...", len(jobs))
 
 	for _, job := range jobs {
-		jobID := linkedInJobIDFromExternal(job.ExternalID)
-		if jobID == "" {
-			continue
-		}
-		desc, err := s.fetchLinkedInDescription(jobID)
-		if err != nil {
+		if err := s.fetchLinkedInDetail(job); err != nil {
 			log.Printf("LinkedIn: could not fetch description for %s: %v", job.Title, err)
-			continue
 		}
-		job.Description = desc
-		// Throttle description fetches to be polite
-		time.Sleep(s.delay)
 	}
 
 	return jobs, nil
 }
 
+// SearchLinkedInPages fetches job summaries (without descriptions) from a
+// LinkedIn search, advancing the guest API's start= offset page by page.
+func (s *Scraper) SearchLinkedInPages(searchURL string, p Pagination) ([]*database.Job, error) {
+	apiURL := linkedInSearchToAPI(searchURL)
+	return s.paginate(p, func(_, offset int) string {
+		return withQueryParam(apiURL, "start", strconv.Itoa(offset))
+	}, firstPageExpects(s.expectedYield("linkedin", searchURL), s.fetchLinkedInJobList))
+}
+
+// fetchLinkedInDetail loads the full description, posting age and applicant
+// count for a job found by fetchLinkedInJobList. The LinkedIn LimitRule keeps
+// detail fetches polite.
+func (s *Scraper) fetchLinkedInDetail(job *database.Job) error {
+	jobID := linkedInJobIDFromExternal(job.ExternalID)
+	if jobID == "" {
+		return nil
+	}
+	doc, err := s.fetchLinkedInPosting(jobID)
+	if err != nil {
+		return err
+	}
+	applyLinkedInPosting(job, doc, s.now())
+	return nil
+}
+
 // fetchLinkedInJobList scrapes the seeMoreJobPostings API for job summaries.
-func (s *Scraper) fetchLinkedInJobList(apiURL string) ([]*database.Job, error) {
+// expected is how many jobs the search usually finds, 0 if unknown.
+func (s *Scraper) fetchLinkedInJobList(apiURL string, expected int) ([]*database.Job, error) {
 	var jobs []*database.Job
 
-	c := s.newCollector("www.linkedin.com", "linkedin.com", "au.linkedin.com")
-	c.Limit(&colly.LimitRule{ //nolint:errcheck
-		DomainGlob:  "*linkedin.com*",
-		Delay:       s.delay,
-		RandomDelay: 2 * time.Second,
-		Parallelism: 1,
-	})
-
-	// Add LinkedIn-specific headers (OnRequest from newCollector already logs the URL)
-	c.OnRequest(func(r *colly.Request) {
-		r.Headers.Set("Referer", "https://www.linkedin.com/jobs/search/")
-		r.Headers.Set("Accept", "text/html,application/xhtml+xml,*/*;q=0.8")
-		r.Headers.Set("Accept-Language", "en-AU,en;q=0.9")
-	})
+	c := s.newLinkedInCollector()
+	probe := &pageProbe{}
+	probe.watch(c, "")
 
 	// Each job card in the seeMoreJobPostings response
 	c.OnHTML("div.base-card", func(e *colly.HTMLElement) {
@@ -266,6 +448,7 @@ func (s *Scraper) fetchLinkedInJobList(apiURL string) ([]*database.Job, error) {
 			Location:   location,
 			Status:     "discovered",
 			ExternalID: "linkedin-" + jobID,
+			ListedAt:   linkedInListedAt(e, s.now()),
 		}
 		job.JobType = database.DetectJobType(job.Title, job.Salary, job.URL)
 
@@ -278,44 +461,58 @@ func (s *Scraper) fetchLinkedInJobList(apiURL string) ([]*database.Job, error) {
 	}
 	c.Wait()
 
+	// The API answers a search with no (more) results with an empty body, so
+	// any markup without job cards is a page the selectors don't understand.
+	probe.container = len(strings.TrimSpace(string(probe.body))) == 0
+	if err := s.checkResultsPage("linkedin", apiURL, len(jobs), probe, expected); err != nil {
+		return nil, err
+	}
 	return jobs, nil
 }
 
-// fetchLinkedInDescription fetches the full job description from LinkedIn's
-// guest jobPosting API (no JS required).
-func (s *Scraper) fetchLinkedInDescription(jobID string) (string, error) {
+// fetchLinkedInPosting fetches a job's page from LinkedIn's guest jobPosting
+// API (no JS required).
+func (s *Scraper) fetchLinkedInPosting(jobID string) (*goquery.Document, error) {
 	apiURL := fmt.Sprintf("https://www.linkedin.com/jobs-guest/jobs/api/jobPosting/%s", jobID)
 
-	req, err := http.NewRequest(http.MethodGet, apiURL, nil)
+	c := s.newLinkedInCollector()
+	handDocuments(c)
+	doc, err := fetchDocument(c, apiURL)
 	if err != nil {
-		return "", err
+		return nil, fmt.Errorf("linkedin description API: %w", err)
 	}
-	req.Header.Set("User-Agent", browserUA)
-	req.Header.Set("Accept", "text/html,application/xhtml+xml,*/*;q=0.8")
-	req.Header.Set("Accept-Language", "en-AU,en;q=0.9")
-	req.Header.Set("Referer", "https://www.linkedin.com/jobs/search/")
+	return doc, nil
+}
 
-	resp, err := s.httpClient.Do(req)
-	if err != nil {
-		return "", err
-	}
-	defer resp.Body.Close()
+// applyLinkedInPosting copies the description, and the posting age and
+// applicant count where LinkedIn shows them, from a jobPosting page into job.
+func applyLinkedInPosting(job *database.Job, doc *goquery.Document, now time.Time) {
+	// The description is inside div.show-more-less-html__markup
+	descHTML, _ := doc.Find("div.show-more-less-html__markup").Html()
+	job.Description = strings.TrimSpace(htmlToText(descHTML))
 
-	if resp.StatusCode != http.StatusOK {
-		io.Copy(io.Discard, resp.Body) //nolint:errcheck
-		return "", fmt.Errorf("linkedin description API returned %d", resp.StatusCode)
+	// The card only gives a date; "2 hours ago" here refines it, while a
+	// coarse "3 weeks ago" would only blur it
+	if listed, ok := parseRelativeAge(doc.Find(".posted-time-ago__text").First().Text(), now); ok &&
+		(job.ListedAt == nil || listed.After(*job.ListedAt)) {
+		job.ListedAt = &listed
 	}
-
-	doc, err := goquery.NewDocumentFromReader(resp.Body)
-	if err != nil {
-		return "", err
+	if n, ok := parseApplicants(doc.Find(".num-applicants__caption").First().Text()); ok {
+		job.Applicants = &n
 	}
+}
 
-	// The description is inside div.show-more-less-html__markup
-	descHTML, _ := doc.Find("div.show-more-less-html__markup").Html()
-	desc := htmlToText(descHTML)
-
-	return strings.TrimSpace(desc), nil
+// linkedInListedAt reads a search card's <time> element: its datetime
+// attribute holds the posting date and its text the age, e.g. "5 days ago".
+func linkedInListedAt(e *colly.HTMLElement, now time.Time) *time.Time {
+	el := e.DOM.Find("time").First()
+	if t, err := time.Parse("2006-01-02", el.AttrOr("datetime", "")); err == nil {
+		return &t
+	}
+	if t, ok := parseRelativeAge(el.Text(), now); ok {
+		return &t
+	}
+	return nil
 }
 
 // htmlToText converts simple HTML to plain text by stripping tags and
@@ -350,6 +547,56 @@ func htmlToText(h string) string {
 	return strings.Join(out, "\n")
 }
 
+// Headings that introduce the candidate requirements section of a job ad
+var requirementHeadings = []string{
+	"requirement", "about you", "what you'll bring", "what you will bring", "what you bring",
+	"skills and experience", "skills & experience", "key skills", "essential", "qualifications",
+	"you will have", "you'll have", "to be successful", "who you are", "selection criteria",
+	"experience required", "what we're looking for", "what we are looking for",
+}
+
+// Headings that start a section after the requirements
+var otherHeadings = []string{
+	"benefit", "what we offer", "what's in it for you", "why join", "perks", "about us",
+	"about the company", "about the team", "how to apply", "the role", "responsibilities",
+	"what you'll do", "what you will do", "desirable", "nice to have",
+}
+
+// extractRequirements pulls the requirements section out of a plain-text job
+// description by looking for common ad headings. It returns "" when the ad has
+// no recognisable requirements section.
+func extractRequirements(desc string) string {
+	var out []string
+	capturing := false
+
+	for _, line := range strings.Split(desc, "\n") {
+		switch {
+		case isHeading(line, requirementHeadings):
+			capturing = true
+		case capturing && isHeading(line, otherHeadings):
+			capturing = false
+		case capturing:
+			out = append(out, line)
+		}
+	}
+	return strings.Join(out, "\n")
+}
+
+// isHeading reports whether a line looks like a section heading containing
+// one of the given keywords.
+func isHeading(line string, keywords []string) bool {
+	line = strings.ToLower(strings.TrimSpace(line))
+	if line == "" || len(line) > 60 || strings.HasSuffix(line, ".") {
+		return false
+	}
+	for _, kw := range keywords {
+		if strings.Contains(line, kw) {
+			return true
+		}
+	}
+	return false
+}
+
 // linkedInSearchToAPI converts a LinkedIn search page URL to the seeMoreJobPostings
 // guest API endpoint, which returns static HTML (no JS required).
 //
@@ -387,17 +634,42 @@ func linkedInJobIDFromExternal(externalID string) string {
 	return strings.TrimPrefix(externalID, "linkedin-")
 }
 
-// ScrapeIndeed scrapes job listings from Indeed
+// ScrapeIndeed scrapes job listings from the first page of an Indeed search
 func (s *Scraper) ScrapeIndeed(searchURL string) ([]*database.Job, error) {
+	return s.scrapeIndeedPage(searchURL, s.expectedYield("indeed", searchURL))
+}
+
+// ScrapeIndeedPages scrapes an Indeed search, following start= result offsets.
+// Indeed pages always advance by 10 results regardless of how many were shown.
+func (s *Scraper) ScrapeIndeedPages(searchURL string, p Pagination) ([]*database.Job, error) {
+	return s.paginate(p, func(page, _ int) string {
+		if page == 0 {
+			return searchURL
+		}
+		return withQueryParam(searchURL, "start", strconv.Itoa(page*indeedPageSize))
+	}, firstPageExpects(s.expectedYield("indeed", searchURL), s.scrapeIndeedPage))
+}
+
+// indeedPageSize is the start= increment between Indeed result pages.
+const indeedPageSize = 10
+
+// indeedResultsContainer is present on every Indeed results page, including
+// one with no matches.
+const indeedResultsContainer = "#mosaic-provider-jobcards, #mosaic-jobResults, .jobsearch-NoResult-messageContainer"
+
+// scrapeIndeedPage scrapes a single Indeed search result page. expected is
+// how many jobs the page usually holds, 0 if unknown.
+func (s *Scraper) scrapeIndeedPage(pageURL string, expected int) ([]*database.Job, error) {
 	var jobs []*database.Job
 
-	c := s.newCollector("indeed.com", "www.indeed.com", "au.indeed.com")
-	c.Limit(&colly.LimitRule{ //nolint:errcheck
+	c := s.newSiteCollector(&colly.LimitRule{
 		DomainGlob:  "*indeed.com*",
 		Delay:       s.delay,
 		RandomDelay: 2 * time.Second,
 		Parallelism: 1,
-	})
+	}, "indeed.com", "www.indeed.com", "au.indeed.com")
+	probe := &pageProbe{}
+	probe.watch(c, indeedResultsContainer)
 
 	c.OnHTML("div.job_seen_beacon, div.slider_container, td.resultContent", func(e *colly.HTMLElement) {
 		var jobURL, title, company, location, salary, jobKey string
@@ -447,6 +719,13 @@ func (s *Scraper) ScrapeIndeed(searchURL string) ([]*database.Job, error) {
 			}
 		})
 
+		var listedAt *time.Time
+		e.ForEach("span.date, span[data-testid='myJobsStateDate']", func(_ int, span *colly.HTMLElement) {
+			if t, ok := parseRelativeAge(span.Text, s.now()); ok && listedAt == nil {
+				listedAt = &t
+			}
+		})
+
 		if title != "" && jobURL != "" {
 			job := &database.Job{
 				Source:   "indeed",
@@ -455,6 +734,7 @@ func (s *Scraper) ScrapeIndeed(searchURL string) ([]*database.Job, error) {
 				Company:  company,
 				Location: location,
 				Salary:   salary,
+				ListedAt: listedAt,
 				Status:   "discovered",
 			}
 			if jobKey != "" {
@@ -514,44 +794,97 @@ func (s *Scraper) ScrapeIndeed(searchURL string) ([]*database.Job, error) {
 		}
 	})
 
-	if err := c.Visit(searchURL); err != nil {
+	if err := c.Visit(pageURL); err != nil {
 		return nil, fmt.Errorf("failed to visit URL: %w", err)
 	}
 	c.Wait()
 
+	if err := s.checkResultsPage("indeed", pageURL, len(jobs), probe, expected); err != nil {
+		return nil, err
+	}
 	return jobs, nil
 }
 
-// SaveJobs saves scraped jobs to the database, skipping existing ones.
-func SaveJobs(jobs []*database.Job, userID uint) error {
+// SaveStats counts what SaveJobs did with the jobs it was given.
+type SaveStats struct {
+	New        int // inserted
+	Duplicates int // already stored for the user
+	Updated    int // duplicates whose listing changed since it was stored
+	Failed     int // database errors
+}
+
+// SaveJobs saves scraped jobs to the database. Jobs that are already stored
+// are refreshed instead: changed fields are recorded as JobRevisions and a
+// closed listing that reappears is marked re-posted. A job that fails to
+// save is counted and skipped; SaveJobs returns an error, counting the jobs
+// left as failed, only when it can't look up the stored jobs at all.
+func SaveJobs(jobs []*database.Job, userID uint) (SaveStats, error) {
 	db := database.GetDB()
+	var stats SaveStats
 
-	for _, job := range jobs {
+	for i, job := range jobs {
 		job.UserID = userID
 
 		var existing database.Job
 		result := db.Where("external_id = ? AND user_id = ?", job.ExternalID, userID).First(&existing)
 		if result.Error == nil {
-			log.Printf("Job already exists: %s", job.Title)
+			stats.Duplicates++
+			revs, err := database.RefreshJob(&existing, job)
+			if err != nil {
+				log.Printf("%v", err)
+				stats.Failed++
+				continue
+			}
+			if len(revs) > 0 {
+				log.Printf("Job changed: %s (%d changes)", existing.Title, len(revs))
+				stats.Updated++
+			} else {
+				log.Printf("Job already exists: %s", job.Title)
+			}
 			continue
 		}
 		if !errors.Is(result.Error, gorm.ErrRecordNotFound) {
-			log.Printf("Database error checking job %s: %v", job.Title, result.Error)
-			continue
+			stats.Failed += len(jobs) - i
+			return stats, fmt.Errorf("failed to look up job %s: %w", job.Title, result.Error)
 		}
 
+		now := time.Now()
+		job.LastSeenAt = &now
+		job.ParseSalary()
+		job.Classify()
 		if err := db.Create(job).Error; err != nil {
 			log.Printf("Failed to save job %s: %v", job.Title, err)
+			stats.Failed++
 			continue
 		}
 		log.Printf("Saved new job: %s", job.Title)
+		stats.New++
 	}
 
-	return nil
+	return stats, nil
+}
+
+// JobExists reports whether a job with the given external ID is already
+// stored for the user.
+func JobExists(externalID string, userID uint) bool {
+	var count int64
+	database.GetDB().Model(&database.Job{}).
+		Where("external_id = ? AND user_id = ?", externalID, userID).
+		Count(&count)
+	return count > 0
 }
 
 // extractJobID extracts a unique job ID from a job board URL.
 func extractJobID(rawURL string) string {
+	// Greenhouse boards embedded on a company's own site carry the board's job
+	// ID as gh_jid, so the embed and boards.greenhouse.io store one job
+	// e.g. https://acme.com/careers?gh_jid=5512345 → "greenhouse-5512345"
+	if u, err := url.Parse(rawURL); err == nil {
+		if id := u.Query().Get("gh_jid"); id != "" {
+			return "greenhouse-" + id
+		}
+	}
+
 	parts := strings.Split(rawURL, "/")
 
 	if strings.Contains(rawURL, "indeed.com") {
@@ -582,6 +915,30 @@ func extractJobID(rawURL string) string {
 		return "linkedin-" + rawURL
 	}
 
+	// ATS boards: IDs are unique across all companies on the same ATS
+	// e.g. https://boards.greenhouse.io/canva/jobs/5512345 → "greenhouse-5512345"
+	//      https://jobs.lever.co/acme/0f5c…  → "lever-0f5c…"
+	//      https://jobs.ashbyhq.com/acme/7d1e… → "ashby-7d1e…"
+	if strings.Contains(rawURL, "greenhouse.io") {
+		if u, err := url.Parse(rawURL); err == nil {
+			segments := strings.Split(strings.Trim(u.Path, "/"), "/")
+			return "greenhouse-" + segments[len(segments)-1]
+		}
+	}
+	for _, ats := range []struct{ host, prefix string }{
+		{"lever.co", "lever-"},
+		{"ashbyhq.com", "ashby-"},
+	} {
+		if strings.Contains(rawURL, ats.host) {
+			if u, err := url.Parse(rawURL); err == nil {
+				segments := strings.Split(strings.Trim(u.Path, "/"), "/")
+				if len(segments) >= 2 {
+					return ats.prefix + segments[1]
+				}
+			}
+		}
+	}
+
 	if strings.Contains(rawURL, "seek.com.au") {
 		for i, part := range parts {
 			if part == "job" && i+1 < len(parts) {
@@ -1,3 +1,5 @@
+//go:build live
+
 package scraper
 
 import (
@@ -8,7 +10,7 @@ import (
 )
 
 // TestSeekHTMLStructure inspects the actual HTML structure from SEEK
-// Run with: go test -v ./internal/scraper -run TestSeekHTMLStructure
+// Run with: go test -tags live -v ./internal/scraper -run TestSeekHTMLStructure
 func TestSeekHTMLStructure(t *testing.T) {
 	// Use a real SEEK search URL
 	// Change this to your actual search URL from config.yaml
@@ -104,7 +106,7 @@ func TestSeekHTMLStructure(t *testing.T) {
 }
 
 // TestSeekSelectorDebug tests specific CSS selectors
-// Run with: go test -v ./internal/scraper -run TestSeekSelectorDebug
+// Run with: go test -tags live -v ./internal/scraper -run TestSeekSelectorDebug
 func TestSeekSelectorDebug(t *testing.T) {
 	searchURL := "https://www.seek.com.au/jobs?keywords=software+engineer&location=melbourne"
 
@@ -168,7 +170,7 @@ func TestSeekSelectorDebug(t *testing.T) {
 }
 
 // TestSeekFullPageDump saves the full HTML to inspect
-// Run with: go test -v ./internal/scraper -run TestSeekFullPageDump
+// Run with: go test -tags live -v ./internal/scraper -run TestSeekFullPageDump
 func TestSeekFullPageDump(t *testing.T) {
 	searchURL := "https://www.seek.com.au/jobs?keywords=software+engineer&location=melbourne"
 
@@ -201,7 +203,7 @@ func TestSeekFullPageDump(t *testing.T) {
 }
 
 // TestSeekScraperWithDebug tests the actual scraper with debug output
-// Run with: go test -v ./internal/scraper -run TestSeekScraperWithDebug
+// Run with: go test -tags live -v ./internal/scraper -run TestSeekScraperWithDebug
 func TestSeekScraperWithDebug(t *testing.T) {
 	searchURL := "https://www.seek.com.au/jobs?keywords=software+engineer&location=melbourne"
 
@@ -0,0 +1,119 @@
+package scraper
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/PuerkitoBio/goquery"
+	"github.com/gocolly/colly/v2"
+	"github.com/guidebee/jobseeker/internal/database"
+)
+
+// SEEK job page selectors (data-automation attributes are SEEK's own test hooks)
+const (
+	seekAdDetailsSelector      = "div[data-automation='jobAdDetails']"
+	seekWorkTypeSelector       = "span[data-automation='job-detail-work-type']"
+	seekClassificationSelector = "span[data-automation='job-detail-classifications']"
+	seekSalarySelector         = "span[data-automation='job-detail-salary']"
+)
+
+// seekListedAtRe finds the listing timestamp SEEK embeds in its page state,
+// e.g. "listedAt":{"__typename":"SeekDateTime","dateTimeUtc":"2025-01-10T02:33:11.000Z"
+var seekListedAtRe = regexp.MustCompile(`"listedAt":\{[^}]*"dateTimeUtc":"([^"]+)"`)
+
+// newSeekDetailCollector creates a collector that fills in the *database.Job
+// passed in each request's context from the SEEK job page it visits.
+func (s *Scraper) newSeekDetailCollector() *colly.Collector {
+	c := s.newSeekCollector()
+
+	c.OnError(func(r *colly.Response, err error) {
+		r.Ctx.Put("err", fmt.Errorf("seek job page returned %d: %w", r.StatusCode, err))
+	})
+
+	c.OnResponse(func(r *colly.Response) {
+		job, ok := r.Ctx.GetAny("job").(*database.Job)
+		if !ok {
+			return
+		}
+		doc, err := goquery.NewDocumentFromReader(bytes.NewReader(r.Body))
+		if err != nil {
+			r.Ctx.Put("err", fmt.Errorf("seek job page: %w", err))
+			return
+		}
+		applySeekDetail(job, doc, string(r.Body), s.now())
+	})
+
+	return c
+}
+
+// fetchSeekDetail visits a SEEK job page through the shared detail collector,
+// so the SEEK LimitRule spaces out consecutive detail fetches.
+func fetchSeekDetail(c *colly.Collector, job *database.Job) error {
+	if job.URL == "" {
+		return nil
+	}
+
+	ctx := colly.NewContext()
+	ctx.Put("job", job)
+	if err := c.Request(http.MethodGet, job.URL, nil, ctx, nil); err != nil {
+		return fmt.Errorf("failed to visit job page: %w", err)
+	}
+	c.Wait()
+
+	if err, ok := ctx.GetAny("err").(error); ok {
+		return err
+	}
+	return nil
+}
+
+// applySeekDetail copies the ad body, work type, classification and listing
+// date from a SEEK job page into job.
+func applySeekDetail(job *database.Job, doc *goquery.Document, body string, now time.Time) {
+	if descHTML, err := doc.Find(seekAdDetailsSelector).First().Html(); err == nil {
+		if desc := htmlToText(descHTML); desc != "" {
+			job.Description = desc
+			job.Requirements = extractRequirements(desc)
+		}
+	}
+
+	job.WorkType = strings.TrimSpace(doc.Find(seekWorkTypeSelector).First().Text())
+	job.Classification = strings.TrimSpace(doc.Find(seekClassificationSelector).First().Text())
+
+	if job.Salary == "" {
+		job.Salary = strings.TrimSpace(doc.Find(seekSalarySelector).First().Text())
+	}
+
+	if listed, ok := seekListedAt(doc, body, now); ok {
+		job.ListedAt = &listed
+	}
+
+	// The work type is a far better signal than title keywords alone
+	if job.JobType == "" || job.JobType == "unknown" {
+		job.JobType = database.DetectJobType(job.Title, job.Salary+" "+job.WorkType, job.URL)
+	}
+}
+
+// seekListedAt reads the listing date from SEEK's embedded page state, falling
+// back to the visible "Posted 3d ago" label.
+func seekListedAt(doc *goquery.Document, body string, now time.Time) (time.Time, bool) {
+	if m := seekListedAtRe.FindStringSubmatch(body); m != nil {
+		if t, err := time.Parse(time.RFC3339, m[1]); err == nil {
+			return t, true
+		}
+	}
+
+	var listed time.Time
+	found := false
+	doc.Find("span").EachWithBreak(func(_ int, sel *goquery.Selection) bool {
+		text := strings.TrimSpace(sel.Text())
+		if strings.HasPrefix(text, "Posted ") {
+			listed, found = parseRelativeAge(text, now)
+		}
+		return !found
+	})
+	return listed, found
+}
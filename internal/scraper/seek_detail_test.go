@@ -0,0 +1,112 @@
+package scraper
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/PuerkitoBio/goquery"
+	"github.com/guidebee/jobseeker/internal/database"
+)
+
+const seekJobPage = `<html><body>
+<h1 data-automation="job-detail-title">Senior Go Engineer</h1>
+<span data-automation="job-detail-classifications">Developers/Programmers (Information &amp; Communication Technology)</span>
+<span data-automation="job-detail-work-type">Contract/Temp</span>
+<span data-automation="job-detail-salary">$900 - $1,050 per day</span>
+<span>Posted 3d ago</span>
+<div data-automation="jobAdDetails"><div>
+<p>We are building a payments platform.</p>
+<p><strong>About you</strong></p>
+<ul><li>5+ years of Go</li><li>Kubernetes in production</li></ul>
+<p><strong>Benefits</strong></p>
+<ul><li>Flexible hours</li></ul>
+</div></div>
+</body></html>`
+
+func TestApplySeekDetail(t *testing.T) {
+	doc, err := goquery.NewDocumentFromReader(strings.NewReader(seekJobPage))
+	if err != nil {
+		t.Fatal(err)
+	}
+	now := time.Date(2025, 3, 10, 9, 0, 0, 0, time.UTC)
+	job := &database.Job{Title: "Senior Go Engineer", JobType: "unknown"}
+
+	applySeekDetail(job, doc, seekJobPage, now)
+
+	if !strings.Contains(job.Description, "payments platform") {
+		t.Errorf("description not extracted: %q", job.Description)
+	}
+	if job.Requirements != "5+ years of Go\nKubernetes in production" {
+		t.Errorf("unexpected requirements: %q", job.Requirements)
+	}
+	if job.WorkType != "Contract/Temp" {
+		t.Errorf("work type = %q", job.WorkType)
+	}
+	if !strings.HasPrefix(job.Classification, "Developers/Programmers") {
+		t.Errorf("classification = %q", job.Classification)
+	}
+	if job.Salary != "$900 - $1,050 per day" {
+		t.Errorf("salary = %q", job.Salary)
+	}
+	if job.JobType != "contract" {
+		t.Errorf("job type = %q, want contract from work type", job.JobType)
+	}
+	if job.ListedAt == nil || !job.ListedAt.Equal(now.Add(-72*time.Hour)) {
+		t.Errorf("listed at = %v", job.ListedAt)
+	}
+}
+
+func TestSeekListedAtPrefersPageState(t *testing.T) {
+	body := `<script>window.SEEK_REDUX_DATA = {"listedAt":{"__typename":"SeekDateTime","dateTimeUtc":"2025-01-10T02:33:11.000Z"}}</script>`
+	doc, _ := goquery.NewDocumentFromReader(strings.NewReader(body))
+
+	listed, ok := seekListedAt(doc, body, time.Now())
+	if !ok || listed.Format(time.RFC3339) != "2025-01-10T02:33:11Z" {
+		t.Errorf("seekListedAt = %v, %v", listed, ok)
+	}
+}
+
+func TestParseRelativeAge(t *testing.T) {
+	now := time.Date(2025, 3, 10, 9, 0, 0, 0, time.UTC)
+	tests := []struct {
+		text string
+		want time.Duration
+	}{
+		{"Posted 3d ago", 72 * time.Hour},
+		{"5 hours ago", 5 * time.Hour},
+		{"45m ago", 45 * time.Minute},
+		{"Posted 30+ days ago", 30 * 24 * time.Hour},
+		{"2 weeks ago", 14 * 24 * time.Hour},
+		{"Just posted", 0},
+	}
+	for _, tt := range tests {
+		got, ok := parseRelativeAge(tt.text, now)
+		if !ok || now.Sub(got) != tt.want {
+			t.Errorf("parseRelativeAge(%q) = %v, %v; want %v ago", tt.text, got, ok, tt.want)
+		}
+	}
+	if _, ok := parseRelativeAge("Featured", now); ok {
+		t.Error("expected no age for a label without one")
+	}
+}
+
+func TestParseApplicants(t *testing.T) {
+	tests := []struct {
+		text string
+		want int
+	}{
+		{"87 applicants", 87},
+		{"Over 200 applicants", 200},
+		{"Be among the first 25 applicants", 0},
+		{"1,024 applicants", 1024},
+	}
+	for _, tt := range tests {
+		if got, ok := parseApplicants(tt.text); !ok || got != tt.want {
+			t.Errorf("parseApplicants(%q) = %d, %v; want %d", tt.text, got, ok, tt.want)
+		}
+	}
+	if _, ok := parseApplicants("Actively hiring"); ok {
+		t.Error("expected no count for a label without one")
+	}
+}
@@ -0,0 +1,149 @@
+package scraper
+
+import (
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"log"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/gocolly/colly/v2"
+)
+
+// ErrSelectorsBroken means a page no longer has the structure a scraper's
+// selectors expect, usually because the board changed its markup. Match it
+// with errors.Is; the error itself is a *SelectorError.
+var ErrSelectorsBroken = errors.New("selectors broken")
+
+// SelectorError describes a page the scraper could not make sense of.
+type SelectorError struct {
+	Board    string
+	URL      string
+	Reason   string
+	Snapshot string // Saved copy of the page, empty if none was written
+}
+
+func (e *SelectorError) Error() string {
+	msg := fmt.Sprintf("%s: selectors broken on %s: %s", e.Board, e.URL, e.Reason)
+	if e.Snapshot != "" {
+		msg += " (page saved to " + e.Snapshot + ")"
+	}
+	return msg
+}
+
+func (e *SelectorError) Unwrap() error { return ErrSelectorsBroken }
+
+// minExpectedYield is the historical yield from which an empty results page
+// is treated as breakage rather than a quiet day.
+const minExpectedYield = 3
+
+// SetSnapshotDir sets where pages with broken selectors are saved. An empty
+// dir disables snapshots.
+func (s *Scraper) SetSnapshotDir(dir string) {
+	s.snapshotDir = dir
+}
+
+// SetExpectedYield installs the lookup for how many jobs a search usually
+// returns, e.g. the median of its recent scans. Empty results from a search
+// with a track record are reported as ErrSelectorsBroken.
+func (s *Scraper) SetExpectedYield(expected func(board, target string) int) {
+	s.expected = expected
+}
+
+// expectedYield returns how many jobs a search usually finds, or 0 if unknown.
+func (s *Scraper) expectedYield(board, target string) int {
+	if s.expected == nil {
+		return 0
+	}
+	return s.expected(board, target)
+}
+
+// pageProbe records what a results page looked like, so that an empty page
+// can be told apart from one the selectors no longer understand.
+type pageProbe struct {
+	mu        sync.Mutex
+	body      []byte
+	err       error
+	container bool
+}
+
+// watch records c's response. containerSelector matches an element that is
+// present on every results page, including one with no results.
+func (p *pageProbe) watch(c *colly.Collector, containerSelector string) {
+	c.OnResponse(func(r *colly.Response) {
+		p.mu.Lock()
+		p.body = r.Body
+		p.mu.Unlock()
+	})
+	c.OnError(func(r *colly.Response, err error) {
+		p.mu.Lock()
+		p.err = fmt.Errorf("%s returned %d: %w", r.Request.URL, r.StatusCode, err)
+		p.mu.Unlock()
+	})
+	if containerSelector != "" {
+		c.OnHTML(containerSelector, func(_ *colly.HTMLElement) {
+			p.mu.Lock()
+			p.container = true
+			p.mu.Unlock()
+		})
+	}
+}
+
+// checkResultsPage decides whether a results page that produced found jobs
+// is believable. A page without its results container, or an empty page for
+// a search that usually finds at least minExpectedYield jobs, means the
+// selectors are broken: the page is saved and a *SelectorError returned.
+func (s *Scraper) checkResultsPage(board, pageURL string, found int, probe *pageProbe, expected int) error {
+	probe.mu.Lock()
+	defer probe.mu.Unlock()
+
+	if probe.err != nil {
+		return probe.err // the page never arrived, so its markup says nothing
+	}
+
+	var reason string
+	switch {
+	case found > 0:
+		return nil
+	case !probe.container:
+		reason = "no job cards and no results container"
+	case expected >= minExpectedYield:
+		reason = fmt.Sprintf("no job cards where this search usually finds %d", expected)
+	default:
+		return nil // a search with genuinely no results
+	}
+	return s.selectorsBroken(board, pageURL, reason, probe.body)
+}
+
+// selectorsBroken saves page as a snapshot and returns the *SelectorError
+// describing it.
+func (s *Scraper) selectorsBroken(board, pageURL, reason string, page []byte) error {
+	serr := &SelectorError{Board: board, URL: pageURL, Reason: reason}
+	if s.snapshotDir != "" && len(page) > 0 {
+		path, err := saveSnapshot(s.snapshotDir, board, pageURL, page)
+		if err != nil {
+			log.Printf("Could not save %s page snapshot: %v", board, err)
+		} else {
+			serr.Snapshot = path
+		}
+	}
+	return serr
+}
+
+// saveSnapshot writes page to dir as <board>-<time>-<url hash>.html.
+func saveSnapshot(dir, board, pageURL string, page []byte) (string, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return "", err
+	}
+	h := fnv.New32a()
+	h.Write([]byte(pageURL)) //nolint:errcheck
+	name := fmt.Sprintf("%s-%s-%08x.html", board, time.Now().Format("20060102-150405"), h.Sum32())
+	path := filepath.Join(dir, name)
+	if err := os.WriteFile(path, page, 0o644); err != nil {
+		return "", err
+	}
+	return path, nil
+}
@@ -0,0 +1,111 @@
+package scraper
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/gocolly/colly/v2"
+	"github.com/guidebee/jobseeker/internal/database"
+)
+
+// probePage fetches page through a page collector with a probe watching for
+// container, and counts the elements matching card.
+func probePage(t *testing.T, s *Scraper, page, container, card string) (string, int, *pageProbe) {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		w.Write([]byte(page)) //nolint:errcheck
+	}))
+	t.Cleanup(srv.Close)
+
+	c := s.newPageCollector()
+	probe := &pageProbe{}
+	probe.watch(c, container)
+	found := 0
+	c.OnHTML(card, func(_ *colly.HTMLElement) { found++ })
+	if err := c.Visit(srv.URL + "/jobs"); err != nil {
+		t.Fatalf("Visit failed: %v", err)
+	}
+	c.Wait()
+	return srv.URL + "/jobs", found, probe
+}
+
+func TestCheckResultsPage(t *testing.T) {
+	const (
+		withCards = `<html><body><div id="results"><article class="job">Go Developer</article></div></body></html>`
+		noResults = `<html><body><div id="results"><p>No jobs match your search</p></div></body></html>`
+		redesign  = `<html><body><main class="new-layout"><section>Go Developer</section></main></body></html>`
+	)
+
+	tests := []struct {
+		name     string
+		page     string
+		expected int
+		broken   bool
+	}{
+		{"jobs found", withCards, 20, false},
+		{"empty search without history", noResults, 0, false},
+		{"empty search that rarely finds jobs", noResults, 1, false},
+		{"empty search that usually finds jobs", noResults, 12, true},
+		{"results container missing", redesign, 0, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			s := NewScraper(0)
+			s.SetSnapshotDir(dir)
+
+			pageURL, found, probe := probePage(t, s, tt.page, "#results", "article.job")
+			err := s.checkResultsPage("seek", pageURL, found, probe, tt.expected)
+			if !tt.broken {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+
+			if !errors.Is(err, ErrSelectorsBroken) {
+				t.Fatalf("expected ErrSelectorsBroken, got %v", err)
+			}
+			var serr *SelectorError
+			if !errors.As(err, &serr) || serr.Board != "seek" || serr.URL != pageURL {
+				t.Fatalf("unexpected selector error: %#v", err)
+			}
+			saved, err := os.ReadFile(serr.Snapshot)
+			if err != nil {
+				t.Fatalf("snapshot not saved: %v", err)
+			}
+			if string(saved) != tt.page {
+				t.Errorf("snapshot = %q, want the page as served", saved)
+			}
+			if !strings.HasPrefix(serr.Snapshot, dir) {
+				t.Errorf("snapshot %s written outside %s", serr.Snapshot, dir)
+			}
+		})
+	}
+}
+
+func TestCheckResultsPageHTTPError(t *testing.T) {
+	probe := &pageProbe{err: errors.New("503 Service Unavailable")}
+	err := NewScraper(0).checkResultsPage("indeed", "https://au.indeed.com/jobs?q=go", 0, probe, 30)
+	if err == nil || errors.Is(err, ErrSelectorsBroken) {
+		t.Errorf("an HTTP failure is not selector breakage, got %v", err)
+	}
+}
+
+func TestFirstPageExpects(t *testing.T) {
+	var got []int
+	scrape := firstPageExpects(7, func(_ string, expected int) ([]*database.Job, error) {
+		got = append(got, expected)
+		return nil, nil
+	})
+	scrape("page1") //nolint:errcheck
+	scrape("page2") //nolint:errcheck
+	if len(got) != 2 || got[0] != 7 || got[1] != 0 {
+		t.Errorf("expected yields passed = %v, want [7 0]", got)
+	}
+}
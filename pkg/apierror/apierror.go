@@ -0,0 +1,52 @@
+// Package apierror describes an unsuccessful response from an AI provider's
+// API, so callers can tell a rate limit or outage (worth retrying) from a bad
+// request or key (not worth retrying). ParseRetryAfter serves any HTTP
+// client that has been asked to wait.
+package apierror
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Error is a response from an AI provider's API with a status other than 200.
+type Error struct {
+	StatusCode int
+	RetryAfter string // the Retry-After header, if any
+	Body       string
+}
+
+// New returns the Error for resp, whose body has already been read.
+func New(resp *http.Response, body []byte) *Error {
+	return &Error{
+		StatusCode: resp.StatusCode,
+		RetryAfter: resp.Header.Get("Retry-After"),
+		Body:       string(body),
+	}
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("API error (status %d): %s", e.StatusCode, e.Body)
+}
+
+// Temporary reports whether the request may succeed if sent again: the
+// provider is rate limiting (429) or failing (5xx).
+func (e *Error) Temporary() bool {
+	return e.StatusCode == http.StatusTooManyRequests || e.StatusCode >= 500
+}
+
+// ParseRetryAfter reads a Retry-After header, given in seconds or as a date,
+// as the time left to wait at now. It reports false if value is neither.
+func ParseRetryAfter(value string, now time.Time) (time.Duration, bool) {
+	value = strings.TrimSpace(value)
+	if secs, err := strconv.Atoi(value); err == nil && secs >= 0 {
+		return time.Duration(secs) * time.Second, true
+	}
+	if at, err := http.ParseTime(value); err == nil {
+		return max(at.Sub(now), 0), true
+	}
+	return 0, false
+}
@@ -7,6 +7,8 @@ import (
 	"io"
 	"net/http"
 	"time"
+
+	"github.com/guidebee/jobseeker/pkg/apierror"
 )
 
 const (
@@ -67,6 +69,13 @@ type Response struct {
 // SendMessage sends a message to Claude and returns the response
 // This is the main function you'll use to interact with Claude
 func (c *Client) SendMessage(userMessage string) (string, error) {
+	reply, _, _, err := c.SendMessageUsage(userMessage)
+	return reply, err
+}
+
+// SendMessageUsage is SendMessage, also returning the tokens Claude billed
+// for the prompt and the reply
+func (c *Client) SendMessageUsage(userMessage string) (reply string, inputTokens, outputTokens int, err error) {
 	// Prepare the request body
 	reqBody := Request{
 		Model:     c.Model,
@@ -82,13 +91,13 @@ func (c *Client) SendMessage(userMessage string) (string, error) {
 	// Convert to JSON
 	jsonData, err := json.Marshal(reqBody)
 	if err != nil {
-		return "", fmt.Errorf("failed to marshal request: %w", err)
+		return "", 0, 0, fmt.Errorf("failed to marshal request: %w", err)
 	}
 
 	// Create HTTP request
 	req, err := http.NewRequest("POST", APIBaseURL, bytes.NewBuffer(jsonData))
 	if err != nil {
-		return "", fmt.Errorf("failed to create request: %w", err)
+		return "", 0, 0, fmt.Errorf("failed to create request: %w", err)
 	}
 
 	// Set headers (required by Anthropic API)
@@ -99,32 +108,32 @@ func (c *Client) SendMessage(userMessage string) (string, error) {
 	// Send request
 	resp, err := c.HTTPClient.Do(req)
 	if err != nil {
-		return "", fmt.Errorf("failed to send request: %w", err)
+		return "", 0, 0, fmt.Errorf("failed to send request: %w", err)
 	}
 	defer resp.Body.Close() // Go tip: always close response bodies!
 
 	// Read response
 	body, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return "", fmt.Errorf("failed to read response: %w", err)
+		return "", 0, 0, fmt.Errorf("failed to read response: %w", err)
 	}
 
 	// Check for errors
 	if resp.StatusCode != http.StatusOK {
-		return "", fmt.Errorf("API error (status %d): %s", resp.StatusCode, string(body))
+		return "", 0, 0, apierror.New(resp, body)
 	}
 
 	// Parse response
 	var apiResp Response
 	err = json.Unmarshal(body, &apiResp)
 	if err != nil {
-		return "", fmt.Errorf("failed to parse response: %w", err)
+		return "", 0, 0, fmt.Errorf("failed to parse response: %w", err)
 	}
 
 	// Extract text from response
 	if len(apiResp.Content) > 0 {
-		return apiResp.Content[0].Text, nil
+		return apiResp.Content[0].Text, apiResp.Usage.InputTokens, apiResp.Usage.OutputTokens, nil
 	}
 
-	return "", fmt.Errorf("no content in response")
+	return "", 0, 0, fmt.Errorf("no content in response")
 }
@@ -0,0 +1,63 @@
+package llm
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+)
+
+// Request is a prompt for Send. Template names the prompt template it was
+// filled from and that template's version, e.g. "job-analysis/v1"; bump the
+// version when the template or the parsing of its replies changes, so that
+// replies cached for the old one are no longer used. A Request without a
+// Template is never cached.
+type Request struct {
+	Template string
+	Prompt   string
+}
+
+// Reply is the answer to a Request.
+type Reply struct {
+	Text         string
+	Model        string // "provider/model" that answered; empty if unknown
+	InputTokens  int    // tokens the provider counted in the prompt
+	OutputTokens int    // tokens it counted in Text
+	Cached       bool   // answered from the Cache, without sending the prompt
+}
+
+// Cache stores replies under their CacheKey, so that a prompt a model has
+// already answered isn't sent to it again. A Cache decides itself how long
+// its entries last.
+type Cache interface {
+	// Get returns the reply stored under key, if there is one
+	Get(key string) (Reply, bool, error)
+	// Put stores reply, given for a prompt of template, under key
+	Put(key, template string, reply Reply) error
+}
+
+// CacheKey returns the key of a reply from model ("provider/model") to
+// prompt, filled from template: a SHA-256 of all three, so equal prompts
+// share a key however long they are.
+func CacheKey(model, template, prompt string) string {
+	h := sha256.New()
+	for _, s := range []string{model, template, prompt} {
+		h.Write([]byte(s))
+		h.Write([]byte{0})
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// usageProvider is a Provider that also reports the tokens a reply took, as
+// all the built-in clients do.
+type usageProvider interface {
+	SendMessageUsage(prompt string) (reply string, inputTokens, outputTokens int, err error)
+}
+
+// sendMessage sends prompt to p, with its token usage if p reports it.
+func sendMessage(p Provider, prompt string) (Reply, error) {
+	if u, ok := p.(usageProvider); ok {
+		text, in, out, err := u.SendMessageUsage(prompt)
+		return Reply{Text: text, InputTokens: in, OutputTokens: out}, err
+	}
+	text, err := p.SendMessage(prompt)
+	return Reply{Text: text}, err
+}
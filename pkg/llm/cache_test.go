@@ -0,0 +1,108 @@
+package llm
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+// mapCache is a Cache in a map.
+type mapCache map[string]Reply
+
+func (m mapCache) Get(key string) (Reply, bool, error) {
+	r, ok := m[key]
+	return r, ok, nil
+}
+
+func (m mapCache) Put(key, _ string, reply Reply) error {
+	m[key] = reply
+	return nil
+}
+
+// usageFake is a fakeProvider that reports token usage.
+type usageFake struct{ fakeProvider }
+
+func (u *usageFake) SendMessageUsage(prompt string) (string, int, int, error) {
+	reply, err := u.SendMessage(prompt)
+	return reply, len(prompt), len(reply), err
+}
+
+func TestChainCache(t *testing.T) {
+	var sleeps []time.Duration
+	c := testChain(&sleeps)
+	cache := mapCache{}
+	c.SetCache(cache)
+	primary := &usageFake{fakeProvider{reply: "score 80"}}
+	local := &fakeProvider{reply: "score 75"}
+	c.Add(primary, "minimax/MiniMax-M2.5", Rate{})
+	c.Add(local, "ollama/llama3.1", Rate{})
+	ctx := context.Background()
+
+	req := Request{Template: "job-analysis/v1", Prompt: "rate this job"}
+	first, err := c.Send(ctx, req)
+	if err != nil || first.Cached || first.InputTokens != len(req.Prompt) || first.OutputTokens != len("score 80") {
+		t.Fatalf("first Send = %+v, %v", first, err)
+	}
+	again, err := c.Send(ctx, req)
+	if err != nil || !again.Cached || again.Text != "score 80" || again.Model != "minimax/MiniMax-M2.5" || again.InputTokens != first.InputTokens {
+		t.Errorf("repeated Send = %+v, %v", again, err)
+	}
+	if primary.calls != 1 {
+		t.Errorf("provider called %d times, want the repeat answered from the cache", primary.calls)
+	}
+
+	// A fallback's cached reply isn't used while the primary answers
+	cache[CacheKey("ollama/llama3.1", "job-analysis/v2", req.Prompt)] = Reply{Text: "score 75"}
+	if r, _ := c.Send(ctx, Request{Template: "job-analysis/v2", Prompt: req.Prompt}); r.Cached || r.Model != "minimax/MiniMax-M2.5" {
+		t.Errorf("Send = %+v, want the primary's reply", r)
+	}
+
+	// Untemplated prompts are always sent
+	for i := 0; i < 2; i++ {
+		if _, err := c.Send(ctx, Request{Prompt: req.Prompt}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if primary.calls != 4 || len(cache) != 3 {
+		t.Errorf("calls = %d, cache entries = %d; want untemplated prompts sent and not stored", primary.calls, len(cache))
+	}
+}
+
+func TestChainCacheFallback(t *testing.T) {
+	var sleeps []time.Duration
+	c := testChain(&sleeps)
+	cache := mapCache{}
+	c.SetCache(cache)
+	primary := &fakeProvider{errs: []error{errors.New("invalid request")}}
+	local := &fakeProvider{reply: "score 70"}
+	c.Add(primary, "minimax/MiniMax-M2.5", Rate{})
+	c.Add(local, "ollama/llama3.1", Rate{})
+
+	// Once the primary fails, the fallback's cached reply saves sending it
+	req := Request{Template: "job-analysis/v1", Prompt: "rate this job"}
+	cache[CacheKey("ollama/llama3.1", req.Template, req.Prompt)] = Reply{Text: "score 75"}
+	r, err := c.Send(context.Background(), req)
+	if err != nil || !r.Cached || r.Text != "score 75" || r.Model != "ollama/llama3.1" {
+		t.Errorf("Send = %+v, %v; want the fallback's cached reply", r, err)
+	}
+	if primary.calls != 1 || local.calls != 0 {
+		t.Errorf("calls = %d primary, %d fallback; want the primary tried and the fallback answered from the cache", primary.calls, local.calls)
+	}
+}
+
+func TestCacheKey(t *testing.T) {
+	key := CacheKey("claude/claude-sonnet-4-5", "resume-keywords/v1", "prompt")
+	if len(key) != 64 || key != CacheKey("claude/claude-sonnet-4-5", "resume-keywords/v1", "prompt") {
+		t.Errorf("CacheKey = %q, want a stable SHA-256", key)
+	}
+	for _, other := range []string{
+		CacheKey("claude/claude-haiku-4-5", "resume-keywords/v1", "prompt"),
+		CacheKey("claude/claude-sonnet-4-5", "resume-keywords/v2", "prompt"),
+		CacheKey("claude/claude-sonnet-4-5", "resume-keywords/v1prompt", ""),
+	} {
+		if other == key {
+			t.Errorf("CacheKey collided for a different model, template or prompt")
+		}
+	}
+}
@@ -0,0 +1,211 @@
+package llm
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"math/rand/v2"
+	"net"
+	"time"
+
+	"github.com/guidebee/jobseeker/pkg/apierror"
+)
+
+// RetryPolicy says how often and how long a Chain retries a provider that
+// failed temporarily (see retryable) before falling back to the next one.
+type RetryPolicy struct {
+	MaxRetries int
+	BaseDelay  time.Duration // before the first retry; doubles with every retry
+	MaxDelay   time.Duration // longest wait; a longer Retry-After falls back instead
+}
+
+// DefaultRetryPolicy retries a provider three times, after about 2s, 4s and 8s.
+var DefaultRetryPolicy = RetryPolicy{
+	MaxRetries: 3,
+	BaseDelay:  2 * time.Second,
+	MaxDelay:   time.Minute,
+}
+
+// Chain is a Provider that sends a prompt to its providers in order, retrying
+// each on rate limits, outages and timeouts, and falling back to the next
+// when one keeps failing. With a Cache, a prompt that the provider it would
+// go to has answered before is not sent again. It is safe for concurrent
+// use; each provider's Rate holds for all callers together.
+type Chain struct {
+	links  []link
+	policy RetryPolicy
+	cache  Cache
+	sleep  func(ctx context.Context, d time.Duration) error
+	jitter func(max time.Duration) time.Duration
+}
+
+type link struct {
+	provider Provider
+	model    string // "provider/model"
+	limiter  *tokenBucket
+}
+
+// NewChain returns an empty Chain retrying with policy; Add its providers.
+func NewChain(policy RetryPolicy) *Chain {
+	return &Chain{
+		policy: policy,
+		sleep:  sleepContext,
+		jitter: func(max time.Duration) time.Duration {
+			if max <= 0 {
+				return 0
+			}
+			return rand.N(max)
+		},
+	}
+}
+
+// Add appends a provider to the chain, sending it at most rate requests.
+// model names it in logs, replies and cache keys, e.g. "minimax/MiniMax-M2.5".
+func (c *Chain) Add(p Provider, model string, rate Rate) {
+	c.links = append(c.links, link{provider: p, model: model, limiter: newTokenBucket(rate)})
+}
+
+// SetCache makes Send look up requests with a Template in cache before
+// sending them, and store the replies; nil turns caching off.
+func (c *Chain) SetCache(cache Cache) {
+	c.cache = cache
+}
+
+// SendMessage sends prompt to the first provider that answers it. It is never
+// cached, having no Template.
+func (c *Chain) SendMessage(prompt string) (string, error) {
+	reply, err := c.Send(context.Background(), Request{Prompt: prompt})
+	return reply.Text, err
+}
+
+// Send sends req to the first provider that answers it, taking each
+// provider's reply from the cache instead when it is there. A fallback's
+// cached reply is only used once the providers before it have failed.
+// Cancelling ctx stops waits for the rate limit or a retry, but never a
+// request already sent: its answer has been paid for. Cache failures are logged, not returned.
+func (c *Chain) Send(ctx context.Context, req Request) (Reply, error) {
+	if len(c.links) == 0 {
+		return Reply{}, errors.New("no AI provider configured")
+	}
+	cached := c.cache != nil && req.Template != ""
+
+	var errs []error
+	for i, l := range c.links {
+		if cached {
+			if reply, ok := c.lookup(l, req); ok {
+				return reply, nil
+			}
+		}
+		reply, err := c.sendWithRetry(ctx, l, req.Prompt)
+		if err == nil {
+			reply.Model = l.model
+			if cached {
+				if err := c.cache.Put(CacheKey(l.model, req.Template, req.Prompt), req.Template, reply); err != nil {
+					log.Printf("Warning: failed to cache reply: %v", err)
+				}
+			}
+			return reply, nil
+		}
+		if ctx.Err() != nil {
+			return Reply{}, err
+		}
+		errs = append(errs, fmt.Errorf("%s: %w", l.model, err))
+		if i+1 < len(c.links) {
+			log.Printf("  %s failed, falling back to %s: %v", l.model, c.links[i+1].model, err)
+		}
+	}
+	return Reply{}, errors.Join(errs...)
+}
+
+// lookup returns l's cached reply to req, if there is one.
+func (c *Chain) lookup(l link, req Request) (Reply, bool) {
+	reply, ok, err := c.cache.Get(CacheKey(l.model, req.Template, req.Prompt))
+	if err != nil {
+		log.Printf("Warning: failed to read reply cache: %v", err)
+		return Reply{}, false
+	}
+	if !ok {
+		return Reply{}, false
+	}
+	reply.Model = l.model
+	reply.Cached = true
+	return reply, true
+}
+
+func (c *Chain) sendWithRetry(ctx context.Context, l link, prompt string) (Reply, error) {
+	for attempt := 0; ; attempt++ {
+		if err := l.limiter.wait(ctx, c.sleep); err != nil {
+			return Reply{}, err
+		}
+		reply, err := sendMessage(l.provider, prompt)
+		if err == nil || !retryable(err) || attempt >= c.policy.MaxRetries {
+			return reply, err
+		}
+		wait, ok := c.retryWait(err, attempt)
+		if !ok {
+			return Reply{}, err
+		}
+		log.Printf("  %s: %v; retrying in %s", l.model, err, wait.Round(100*time.Millisecond))
+		if err := c.sleep(ctx, wait); err != nil {
+			return Reply{}, err
+		}
+	}
+}
+
+func sleepContext(ctx context.Context, d time.Duration) error {
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-t.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// retryWait returns how long to wait before retrying after err: the
+// provider's Retry-After, or an exponential backoff with jitter so that
+// concurrent callers don't retry in step. It reports false if the provider
+// asks for longer than MaxDelay.
+func (c *Chain) retryWait(err error, attempt int) (time.Duration, bool) {
+	var apiErr *apierror.Error
+	if errors.As(err, &apiErr) && apiErr.RetryAfter != "" {
+		if wait, ok := apierror.ParseRetryAfter(apiErr.RetryAfter, time.Now()); ok {
+			return wait, wait <= c.policy.MaxDelay
+		}
+	}
+	backoff := c.policy.BaseDelay << attempt
+	if backoff > c.policy.MaxDelay || backoff <= 0 {
+		backoff = c.policy.MaxDelay
+	}
+	// Equal jitter: half the backoff, plus up to the other half at random
+	return backoff/2 + c.jitter(backoff/2), true
+}
+
+// retryable reports whether err may pass if the prompt is sent again: a rate
+// limit, a server error or a timeout.
+func retryable(err error) bool {
+	var apiErr *apierror.Error
+	if errors.As(err, &apiErr) {
+		return apiErr.Temporary()
+	}
+	if errors.Is(err, context.DeadlineExceeded) {
+		return true
+	}
+	var netErr net.Error
+	return errors.As(err, &netErr) && netErr.Timeout()
+}
+
+// Send sends req to p. A Chain answers it as in Chain.Send; any other
+// Provider is sent the prompt as it is, uncached. ctx is honoured as in
+// Chain.Send.
+func Send(ctx context.Context, p Provider, req Request) (Reply, error) {
+	if c, ok := p.(*Chain); ok {
+		return c.Send(ctx, req)
+	}
+	if err := ctx.Err(); err != nil {
+		return Reply{}, err
+	}
+	return sendMessage(p, req.Prompt)
+}
@@ -0,0 +1,166 @@
+package llm
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/guidebee/jobseeker/pkg/apierror"
+	"github.com/guidebee/jobseeker/pkg/claude"
+)
+
+// fakeProvider answers with its errs in turn, then with reply.
+type fakeProvider struct {
+	errs  []error
+	reply string
+	calls int
+}
+
+func (f *fakeProvider) SendMessage(string) (string, error) {
+	f.calls++
+	if f.calls <= len(f.errs) {
+		return "", f.errs[f.calls-1]
+	}
+	return f.reply, nil
+}
+
+// testChain returns a chain whose waits are recorded in sleeps and whose
+// jitter is always the most it may be.
+func testChain(sleeps *[]time.Duration) *Chain {
+	c := NewChain(RetryPolicy{MaxRetries: 2, BaseDelay: time.Second, MaxDelay: 30 * time.Second})
+	c.sleep = func(_ context.Context, d time.Duration) error {
+		*sleeps = append(*sleeps, d)
+		return nil
+	}
+	c.jitter = func(max time.Duration) time.Duration { return max }
+	return c
+}
+
+func TestChainRetries(t *testing.T) {
+	var sleeps []time.Duration
+	c := testChain(&sleeps)
+	p := &fakeProvider{
+		errs: []error{
+			&apierror.Error{StatusCode: 429, RetryAfter: "7"},
+			fmt.Errorf("failed to send request: %w", context.DeadlineExceeded),
+		},
+		reply: "ok",
+	}
+	c.Add(p, "minimax/MiniMax-M2.5", Rate{})
+
+	reply, err := c.Send(context.Background(), Request{Prompt: "prompt"})
+	if err != nil || reply.Text != "ok" || reply.Model != "minimax/MiniMax-M2.5" {
+		t.Fatalf("Send = %+v, %v", reply, err)
+	}
+	// The Retry-After as given, then the second backoff (2s) at its most jitter
+	if !slices.Equal(sleeps, []time.Duration{7 * time.Second, 2 * time.Second}) {
+		t.Errorf("waited %v", sleeps)
+	}
+}
+
+func TestChainFallsBack(t *testing.T) {
+	var sleeps []time.Duration
+	c := testChain(&sleeps)
+	outage := &apierror.Error{StatusCode: 503}
+	primary := &fakeProvider{errs: []error{outage, outage, outage}}
+	badKey := &fakeProvider{errs: []error{&apierror.Error{StatusCode: 401}}}
+	local := &fakeProvider{reply: "local answer"}
+	c.Add(primary, "minimax/MiniMax-M2.5", Rate{})
+	c.Add(badKey, "claude/claude-sonnet-4-5", Rate{})
+	c.Add(local, "ollama/llama3.1", Rate{})
+
+	reply, err := c.Send(context.Background(), Request{Prompt: "prompt"})
+	if err != nil || reply.Text != "local answer" || reply.Model != "ollama/llama3.1" {
+		t.Fatalf("Send = %+v, %v", reply, err)
+	}
+	if primary.calls != 3 || badKey.calls != 1 {
+		t.Errorf("calls = %d, %d; want the outage retried twice and the 401 not at all", primary.calls, badKey.calls)
+	}
+	if len(sleeps) != 2 {
+		t.Errorf("waited %v, want two backoffs", sleeps)
+	}
+}
+
+func TestChainGivesUp(t *testing.T) {
+	var sleeps []time.Duration
+	c := testChain(&sleeps)
+	c.Add(&fakeProvider{errs: []error{&apierror.Error{StatusCode: 429, RetryAfter: "3600"}}}, "minimax", Rate{})
+	c.Add(&fakeProvider{errs: []error{errors.New("no content in response")}}, "claude", Rate{})
+
+	_, err := c.Send(context.Background(), Request{Prompt: "prompt"})
+	var apiErr *apierror.Error
+	if err == nil || !errors.As(err, &apiErr) || apiErr.StatusCode != 429 {
+		t.Errorf("Send error = %v, want every provider's error", err)
+	}
+	if len(sleeps) != 0 {
+		t.Errorf("waited %v for an hour's Retry-After", sleeps)
+	}
+}
+
+func TestRetryWaitJitter(t *testing.T) {
+	c := NewChain(DefaultRetryPolicy)
+	for attempt := 0; attempt < 8; attempt++ {
+		backoff := min(DefaultRetryPolicy.BaseDelay<<attempt, DefaultRetryPolicy.MaxDelay)
+		wait, ok := c.retryWait(errors.New("timeout"), attempt)
+		if !ok || wait < backoff/2 || wait > backoff {
+			t.Errorf("attempt %d: waited %v, want %v to %v", attempt, wait, backoff/2, backoff)
+		}
+	}
+}
+
+func TestSettingsNewChain(t *testing.T) {
+	t.Setenv("MINIMAX_API_KEY", "")
+	t.Setenv("TEST_LLM_KEY", "secret")
+	t.Setenv("CLAUDE_MODEL", "")
+
+	s := Settings{TaskAnalysis: {Fallback: []Config{{Provider: ProviderClaude, APIKeyEnv: "TEST_LLM_KEY"}, {Provider: ProviderOllama, Model: "qwen2.5:14b"}}}}
+	p, err := s.New(TaskAnalysis)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var models []string
+	for _, l := range p.(*Chain).links {
+		models = append(models, l.model)
+	}
+	// MiniMax has no key, so the chain starts at its first fallback
+	if want := []string{"claude/" + claude.DefaultModel, "ollama/qwen2.5:14b"}; !slices.Equal(models, want) {
+		t.Errorf("chain = %v, want %v", models, want)
+	}
+
+	if _, err := (Settings{}).New(TaskAnalysis); err == nil {
+		t.Error("New succeeded without any usable provider")
+	}
+}
+
+func TestChainRateLimit(t *testing.T) {
+	var sleeps []time.Duration
+	c := testChain(&sleeps)
+	p := &fakeProvider{reply: "ok"}
+	c.Add(p, "minimax", Rate{PerMinute: 30, Burst: 2})
+	now := time.Date(2025, 3, 10, 9, 0, 0, 0, time.UTC)
+	c.links[0].limiter.now = func() time.Time { return now }
+
+	for i := 0; i < 4; i++ {
+		if _, err := c.Send(context.Background(), Request{Prompt: "prompt"}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// Two go at once, then one every 2s; the clock is frozen, so the
+	// fourth waits for two tokens
+	if !slices.Equal(sleeps, []time.Duration{2 * time.Second, 4 * time.Second}) {
+		t.Errorf("waited %v", sleeps)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	c.sleep = sleepContext
+	if _, err := c.Send(ctx, Request{Prompt: "prompt"}); !errors.Is(err, context.Canceled) {
+		t.Errorf("Send while waiting for a token returned %v, want context.Canceled", err)
+	}
+	if p.calls != 4 {
+		t.Errorf("provider called %d times, want 4", p.calls)
+	}
+}
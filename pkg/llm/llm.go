@@ -0,0 +1,232 @@
+// Package llm puts the AI providers behind one interface, so each AI task
+// can be pointed at a different provider and model from config.
+package llm
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+
+	"github.com/guidebee/jobseeker/pkg/claude"
+	"github.com/guidebee/jobseeker/pkg/minimax"
+	"github.com/guidebee/jobseeker/pkg/ollama"
+	"github.com/guidebee/jobseeker/pkg/openai"
+)
+
+// Provider sends a prompt to a language model and returns its text reply.
+// claude.Client, minimax.Client, openai.Client and ollama.Client are
+// Providers.
+type Provider interface {
+	SendMessage(prompt string) (string, error)
+}
+
+// Task names an AI task whose provider and model are configured separately.
+type Task string
+
+const (
+	// TaskAnalysis scores scraped jobs in bulk (analyze)
+	TaskAnalysis Task = "analysis"
+	// TaskJDAnalysis analyses a single job description and writes cover
+	// letters (checkjd, tailorcv)
+	TaskJDAnalysis Task = "jd_analysis"
+	// TaskKeywords extracts search keywords from resumes and infers skills
+	// from LinkedIn profiles (init, scan, keywords, linkedin)
+	TaskKeywords Task = "keywords"
+)
+
+// Provider names accepted in Config.Provider.
+const (
+	ProviderClaude  = "claude"
+	ProviderMiniMax = "minimax"
+	ProviderOpenAI  = "openai" // OpenAI or any OpenAI-compatible server
+	ProviderOllama  = "ollama"
+)
+
+// Config selects the provider and model for a task. Empty fields take the
+// task's or provider's defaults.
+type Config struct {
+	Provider  string `yaml:"provider"`    // claude, minimax, openai or ollama
+	Model     string `yaml:"model"`       // default: <PROVIDER>_MODEL, else the provider's default
+	APIKeyEnv string `yaml:"api_key_env"` // environment variable holding the API key
+	BaseURL   string `yaml:"base_url"`    // openai and ollama: the server to use
+
+	// Request cap for the provider, shared by all concurrent requests
+	// (0 = none); Burst lets that many go at once after a quiet spell
+	RequestsPerMinute int `yaml:"requests_per_minute"`
+	Burst             int `yaml:"burst"`
+
+	// Providers tried in order when this one keeps failing (see Chain)
+	Fallback []Config `yaml:"fallback"`
+}
+
+// Settings holds the configured Config of each task, under the "ai:" key of
+// config.yaml.
+type Settings map[Task]Config
+
+// defaultProviders keeps each task on the provider it used before it could be
+// configured: MiniMax for bulk analysis, Claude for the rest.
+var defaultProviders = map[Task]string{
+	TaskAnalysis:   ProviderMiniMax,
+	TaskJDAnalysis: ProviderClaude,
+	TaskKeywords:   ProviderClaude,
+}
+
+// defaultKeyEnvs is the environment variable each provider's key is read
+// from when a Config doesn't name one.
+var defaultKeyEnvs = map[string]string{
+	ProviderClaude:  "CLAUDE_API_KEY",
+	ProviderMiniMax: "MINIMAX_API_KEY",
+	ProviderOpenAI:  "OPENAI_API_KEY",
+}
+
+// defaultModelEnvs is the environment variable that overrides each
+// provider's default model when a Config doesn't name one.
+var defaultModelEnvs = map[string]string{
+	ProviderClaude:  "CLAUDE_MODEL",
+	ProviderMiniMax: "MINIMAX_MODEL",
+	ProviderOpenAI:  "OPENAI_MODEL",
+	ProviderOllama:  "OLLAMA_MODEL",
+}
+
+// For returns the Config of task with its defaults filled in, fallbacks
+// included. A task that changes provider without naming a key variable uses
+// that provider's.
+func (s Settings) For(task Task) Config {
+	cfg := s[task]
+	if strings.TrimSpace(cfg.Provider) == "" {
+		cfg.Provider = defaultProviders[task]
+	}
+	cfg = cfg.withDefaults()
+	if len(cfg.Fallback) > 0 {
+		fallback := make([]Config, len(cfg.Fallback))
+		for i, f := range cfg.Fallback {
+			fallback[i] = f.withDefaults()
+		}
+		cfg.Fallback = fallback
+	}
+	return cfg
+}
+
+func (c Config) withDefaults() Config {
+	c.Provider = strings.ToLower(strings.TrimSpace(c.Provider))
+	if c.APIKeyEnv == "" {
+		c.APIKeyEnv = defaultKeyEnvs[c.Provider]
+	}
+	return c
+}
+
+// New returns the Provider configured for task: a Chain of its provider and
+// fallbacks, each retried per DefaultRetryPolicy, with API keys read from the
+// environment. A provider that can't be set up (say its key isn't set) is
+// left out of the chain with a warning; New fails only if none can be.
+func (s Settings) New(task Task) (Provider, error) {
+	cfg := s.For(task)
+	chain := NewChain(DefaultRetryPolicy)
+	var errs []error
+	for _, c := range append([]Config{cfg}, cfg.Fallback...) {
+		p, err := New(c)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", c.Provider, err))
+			continue
+		}
+		chain.Add(p, modelName(c.Provider, p), Rate{PerMinute: c.RequestsPerMinute, Burst: c.Burst})
+	}
+	if len(chain.links) == 0 {
+		return nil, fmt.Errorf("failed to set up %s provider: %w", task, errors.Join(errs...))
+	}
+	for _, err := range errs {
+		log.Printf("Warning: %s provider skipped: %v", task, err)
+	}
+	return chain, nil
+}
+
+// modelName names the provider and model p sends prompts to, e.g.
+// "minimax/MiniMax-M2.5".
+func modelName(provider string, p Provider) string {
+	var model string
+	switch c := p.(type) {
+	case *claude.Client:
+		model = c.Model
+	case *minimax.Client:
+		model = c.Model
+	case *openai.Client:
+		model = c.Model
+	case *ollama.Client:
+		model = c.Model
+	}
+	if model == "" {
+		return provider
+	}
+	return provider + "/" + model
+}
+
+// New returns the Provider cfg describes, with its API key read from the
+// environment. Ollama needs no key, and neither does an OpenAI-compatible
+// server at a BaseURL of its own (a key is sent if set).
+func New(cfg Config) (Provider, error) {
+	keyEnv := cfg.APIKeyEnv
+	if keyEnv == "" {
+		keyEnv = defaultKeyEnvs[cfg.Provider]
+	}
+	model := cfg.Model
+	if model == "" {
+		model = os.Getenv(defaultModelEnvs[cfg.Provider])
+	}
+
+	switch cfg.Provider {
+	case ProviderClaude:
+		apiKey, err := requireKey(keyEnv)
+		if err != nil {
+			return nil, err
+		}
+		client := claude.NewClient(apiKey)
+		if model != "" {
+			client.Model = model
+		}
+		return client, nil
+	case ProviderMiniMax:
+		apiKey, err := requireKey(keyEnv)
+		if err != nil {
+			return nil, err
+		}
+		client := minimax.NewClient(apiKey)
+		if model != "" {
+			client.Model = model
+		}
+		return client, nil
+	case ProviderOpenAI:
+		apiKey := os.Getenv(keyEnv)
+		if cfg.BaseURL == "" {
+			// OpenAI itself always needs a key
+			var err error
+			if apiKey, err = requireKey(keyEnv); err != nil {
+				return nil, err
+			}
+		}
+		client := openai.NewClient(cfg.BaseURL, apiKey)
+		if model != "" {
+			client.Model = model
+		}
+		return client, nil
+	case ProviderOllama:
+		client := ollama.NewClient(cfg.BaseURL)
+		if model != "" {
+			client.Model = model
+		}
+		return client, nil
+	}
+	return nil, fmt.Errorf("unknown provider %q (want %s, %s, %s or %s)", cfg.Provider, ProviderClaude, ProviderMiniMax, ProviderOpenAI, ProviderOllama)
+}
+
+func requireKey(env string) (string, error) {
+	if env == "" {
+		return "", fmt.Errorf("no API key variable configured")
+	}
+	key := os.Getenv(env)
+	if key == "" {
+		return "", fmt.Errorf("%s not set", env)
+	}
+	return key, nil
+}
@@ -0,0 +1,91 @@
+package llm
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/guidebee/jobseeker/pkg/claude"
+	"github.com/guidebee/jobseeker/pkg/minimax"
+	"github.com/guidebee/jobseeker/pkg/ollama"
+	"github.com/guidebee/jobseeker/pkg/openai"
+)
+
+func TestSettingsFor(t *testing.T) {
+	s := Settings{
+		TaskAnalysis:   {Provider: "Claude", Model: "claude-haiku-4-5"},
+		TaskKeywords:   {Provider: "minimax", APIKeyEnv: "KEYWORDS_KEY"},
+		TaskJDAnalysis: {Fallback: []Config{{Provider: "OpenAI", BaseURL: "http://localhost:1234/v1"}, {Provider: "ollama"}}},
+	}
+	tests := []struct {
+		task Task
+		want Config
+	}{
+		{TaskAnalysis, Config{Provider: ProviderClaude, Model: "claude-haiku-4-5", APIKeyEnv: "CLAUDE_API_KEY"}},
+		{TaskJDAnalysis, Config{Provider: ProviderClaude, APIKeyEnv: "CLAUDE_API_KEY", Fallback: []Config{
+			{Provider: ProviderOpenAI, APIKeyEnv: "OPENAI_API_KEY", BaseURL: "http://localhost:1234/v1"},
+			{Provider: ProviderOllama},
+		}}},
+		{TaskKeywords, Config{Provider: ProviderMiniMax, APIKeyEnv: "KEYWORDS_KEY"}},
+	}
+	for _, tt := range tests {
+		if got := s.For(tt.task); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("For(%s) = %+v, want %+v", tt.task, got, tt.want)
+		}
+	}
+	if got := Settings(nil).For(TaskAnalysis); got.Provider != ProviderMiniMax || got.APIKeyEnv != "MINIMAX_API_KEY" {
+		t.Errorf("default analysis config = %+v, want MiniMax", got)
+	}
+}
+
+func TestNew(t *testing.T) {
+	t.Setenv("TEST_LLM_KEY", "secret")
+	t.Setenv("MINIMAX_MODEL", "")
+
+	p, err := New(Config{Provider: ProviderClaude, Model: "claude-haiku-4-5", APIKeyEnv: "TEST_LLM_KEY"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c, ok := p.(*claude.Client); !ok || c.Model != "claude-haiku-4-5" || c.APIKey != "secret" {
+		t.Errorf("New returned %#v", p)
+	}
+
+	p, err = New(Config{Provider: ProviderMiniMax, APIKeyEnv: "TEST_LLM_KEY"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c, ok := p.(*minimax.Client); !ok || c.Model != minimax.DefaultModel {
+		t.Errorf("New returned %#v", p)
+	}
+
+	if _, err := New(Config{Provider: ProviderClaude, APIKeyEnv: "TEST_LLM_UNSET"}); err == nil {
+		t.Error("New succeeded without an API key")
+	}
+	if _, err := New(Config{Provider: "gpt", APIKeyEnv: "TEST_LLM_KEY"}); err == nil {
+		t.Error("New accepted an unknown provider")
+	}
+}
+
+func TestNewLocal(t *testing.T) {
+	t.Setenv("OPENAI_API_KEY", "")
+	t.Setenv("OPENAI_MODEL", "")
+	t.Setenv("OLLAMA_MODEL", "")
+
+	if _, err := New(Config{Provider: ProviderOpenAI}); err == nil {
+		t.Error("OpenAI itself was set up without an API key")
+	}
+	p, err := New(Config{Provider: ProviderOpenAI, BaseURL: "http://localhost:8000/v1", Model: "qwen2.5-14b"})
+	if err != nil {
+		t.Fatalf("an OpenAI-compatible server needs no key: %v", err)
+	}
+	if c, ok := p.(*openai.Client); !ok || c.BaseURL != "http://localhost:8000/v1" || c.Model != "qwen2.5-14b" || c.APIKey != "" {
+		t.Errorf("New returned %#v", p)
+	}
+
+	p, err = New(Settings{TaskAnalysis: {Provider: "Ollama"}}.For(TaskAnalysis))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c, ok := p.(*ollama.Client); !ok || c.BaseURL != ollama.DefaultBaseURL || c.Model != ollama.DefaultModel {
+		t.Errorf("New returned %#v", p)
+	}
+}
@@ -0,0 +1,73 @@
+package llm
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Rate caps the requests sent to one provider. The zero Rate is unlimited.
+type Rate struct {
+	PerMinute int // average requests a minute
+	Burst     int // requests that may go at once after a quiet spell (default 1)
+}
+
+// tokenBucket enforces a Rate across every caller of a provider: a token is
+// added PerMinute/60 times a second, up to Burst, and each request takes one.
+type tokenBucket struct {
+	mu       sync.Mutex
+	perSec   float64
+	capacity float64
+	tokens   float64
+	last     time.Time
+	now      func() time.Time
+}
+
+func newTokenBucket(r Rate) *tokenBucket {
+	if r.PerMinute <= 0 {
+		return nil
+	}
+	burst := max(r.Burst, 1)
+	return &tokenBucket{
+		perSec:   float64(r.PerMinute) / 60,
+		capacity: float64(burst),
+		tokens:   float64(burst),
+		now:      time.Now,
+	}
+}
+
+// reserve takes a token, going into debt if there is none, and returns how
+// long to wait until the debt is paid off.
+func (b *tokenBucket) reserve() time.Duration {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	now := b.now()
+	if !b.last.IsZero() {
+		b.tokens = min(b.capacity, b.tokens+now.Sub(b.last).Seconds()*b.perSec)
+	}
+	b.last = now
+	b.tokens--
+	if b.tokens >= 0 {
+		return 0
+	}
+	return time.Duration(-b.tokens / b.perSec * float64(time.Second))
+}
+
+// wait blocks until a request may be sent. A request given up on while
+// waiting hands its token back.
+func (b *tokenBucket) wait(ctx context.Context, sleep func(context.Context, time.Duration) error) error {
+	if b == nil {
+		return nil
+	}
+	d := b.reserve()
+	if d <= 0 {
+		return nil
+	}
+	if err := sleep(ctx, d); err != nil {
+		b.mu.Lock()
+		b.tokens++
+		b.mu.Unlock()
+		return err
+	}
+	return nil
+}
@@ -7,6 +7,8 @@ import (
 	"io"
 	"net/http"
 	"time"
+
+	"github.com/guidebee/jobseeker/pkg/apierror"
 )
 
 const (
@@ -60,6 +62,13 @@ type response struct {
 
 // SendMessage sends a user message to MiniMax and returns the text response
 func (c *Client) SendMessage(userMessage string) (string, error) {
+	reply, _, _, err := c.SendMessageUsage(userMessage)
+	return reply, err
+}
+
+// SendMessageUsage is SendMessage, also returning the tokens used by the
+// prompt and the reply
+func (c *Client) SendMessageUsage(userMessage string) (reply string, inputTokens, outputTokens int, err error) {
 	reqBody := request{
 		Model: c.Model,
 		Messages: []message{
@@ -70,12 +79,12 @@ func (c *Client) SendMessage(userMessage string) (string, error) {
 
 	jsonData, err := json.Marshal(reqBody)
 	if err != nil {
-		return "", fmt.Errorf("failed to marshal request: %w", err)
+		return "", 0, 0, fmt.Errorf("failed to marshal request: %w", err)
 	}
 
 	req, err := http.NewRequest("POST", APIBaseURL, bytes.NewBuffer(jsonData))
 	if err != nil {
-		return "", fmt.Errorf("failed to create request: %w", err)
+		return "", 0, 0, fmt.Errorf("failed to create request: %w", err)
 	}
 
 	req.Header.Set("Content-Type", "application/json")
@@ -83,27 +92,27 @@ func (c *Client) SendMessage(userMessage string) (string, error) {
 
 	resp, err := c.HTTPClient.Do(req)
 	if err != nil {
-		return "", fmt.Errorf("failed to send request: %w", err)
+		return "", 0, 0, fmt.Errorf("failed to send request: %w", err)
 	}
 	defer resp.Body.Close()
 
 	body, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return "", fmt.Errorf("failed to read response: %w", err)
+		return "", 0, 0, fmt.Errorf("failed to read response: %w", err)
 	}
 
 	if resp.StatusCode != http.StatusOK {
-		return "", fmt.Errorf("API error (status %d): %s", resp.StatusCode, string(body))
+		return "", 0, 0, apierror.New(resp, body)
 	}
 
 	var apiResp response
 	if err := json.Unmarshal(body, &apiResp); err != nil {
-		return "", fmt.Errorf("failed to parse response: %w", err)
+		return "", 0, 0, fmt.Errorf("failed to parse response: %w", err)
 	}
 
 	if len(apiResp.Choices) > 0 {
-		return apiResp.Choices[0].Message.Content, nil
+		return apiResp.Choices[0].Message.Content, apiResp.Usage.PromptTokens, apiResp.Usage.CompletionTokens, nil
 	}
 
-	return "", fmt.Errorf("no content in response")
+	return "", 0, 0, fmt.Errorf("no content in response")
 }
\ No newline at end of file
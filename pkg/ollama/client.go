@@ -0,0 +1,121 @@
+package ollama
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/guidebee/jobseeker/pkg/apierror"
+)
+
+const (
+	DefaultBaseURL = "http://localhost:11434"
+	DefaultModel   = "llama3.1"
+)
+
+// Client handles communication with a local Ollama server
+type Client struct {
+	BaseURL    string
+	Model      string
+	HTTPClient *http.Client
+}
+
+// NewClient creates a new client for the Ollama server at baseURL, or the
+// local default if baseURL is empty
+func NewClient(baseURL string) *Client {
+	if baseURL == "" {
+		baseURL = DefaultBaseURL
+	}
+	return &Client{
+		BaseURL: strings.TrimSuffix(baseURL, "/"),
+		Model:   DefaultModel,
+		HTTPClient: &http.Client{
+			// A model loading into memory can take minutes to answer the first prompt
+			Timeout: 5 * time.Minute,
+		},
+	}
+}
+
+type message struct {
+	Role    string `json:"role"`
+	Content string `json:"content"`
+}
+
+type request struct {
+	Model    string    `json:"model"`
+	Messages []message `json:"messages"`
+	Stream   bool      `json:"stream"`
+}
+
+type response struct {
+	Message struct {
+		Content string `json:"content"`
+	} `json:"message"`
+	Done            bool   `json:"done"`
+	Error           string `json:"error"`
+	PromptEvalCount int    `json:"prompt_eval_count"`
+	EvalCount       int    `json:"eval_count"`
+}
+
+// SendMessage sends a user message to Ollama's chat endpoint and returns the
+// text response
+func (c *Client) SendMessage(userMessage string) (string, error) {
+	reply, _, _, err := c.SendMessageUsage(userMessage)
+	return reply, err
+}
+
+// SendMessageUsage is SendMessage, also returning the tokens in the prompt
+// and the reply
+func (c *Client) SendMessageUsage(userMessage string) (reply string, inputTokens, outputTokens int, err error) {
+	reqBody := request{
+		Model: c.Model,
+		Messages: []message{
+			{Role: "user", Content: userMessage},
+		},
+		Stream: false,
+	}
+
+	jsonData, err := json.Marshal(reqBody)
+	if err != nil {
+		return "", 0, 0, fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	req, err := http.NewRequest("POST", c.BaseURL+"/api/chat", bytes.NewBuffer(jsonData))
+	if err != nil {
+		return "", 0, 0, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return "", 0, 0, fmt.Errorf("failed to send request (is Ollama running at %s?): %w", c.BaseURL, err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", 0, 0, fmt.Errorf("failed to read response: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return "", 0, 0, apierror.New(resp, body)
+	}
+
+	var apiResp response
+	if err := json.Unmarshal(body, &apiResp); err != nil {
+		return "", 0, 0, fmt.Errorf("failed to parse response: %w", err)
+	}
+	if apiResp.Error != "" {
+		return "", 0, 0, fmt.Errorf("API error: %s", apiResp.Error)
+	}
+
+	if apiResp.Message.Content != "" {
+		return apiResp.Message.Content, apiResp.PromptEvalCount, apiResp.EvalCount, nil
+	}
+
+	return "", 0, 0, fmt.Errorf("no content in response")
+}
@@ -0,0 +1,64 @@
+package ollama
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestSendMessage(t *testing.T) {
+	var got request
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/api/chat" {
+			t.Errorf("request to %s %s", r.Method, r.URL.Path)
+		}
+		if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+			t.Error(err)
+		}
+		w.Write([]byte(`{"model":"llama3.1","message":{"role":"assistant","content":"{\"match_score\": 72}"},"done":true,"prompt_eval_count":30,"eval_count":8}`)) //nolint:errcheck
+	}))
+	defer srv.Close()
+
+	c := NewClient(srv.URL + "/")
+	reply, in, out, err := c.SendMessageUsage("Score this job")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if reply != `{"match_score": 72}` || in != 30 || out != 8 {
+		t.Errorf("reply = %q, tokens %d in, %d out", reply, in, out)
+	}
+	if got.Model != DefaultModel || got.Stream || len(got.Messages) != 1 || got.Messages[0].Content != "Score this job" {
+		t.Errorf("request = %+v", got)
+	}
+}
+
+func TestSendMessageErrors(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req request
+		json.NewDecoder(r.Body).Decode(&req) //nolint:errcheck
+		if req.Model == "missing" {
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"error":"model \"missing\" not found, try pulling it first"}`)) //nolint:errcheck
+			return
+		}
+		w.Write([]byte(`{"error":"out of memory"}`)) //nolint:errcheck
+	}))
+	defer srv.Close()
+
+	c := NewClient(srv.URL)
+	c.Model = "missing"
+	if _, err := c.SendMessage("hi"); err == nil || !strings.Contains(err.Error(), "try pulling it") {
+		t.Errorf("error = %v, want Ollama's message", err)
+	}
+	c.Model = DefaultModel
+	if _, err := c.SendMessage("hi"); err == nil || !strings.Contains(err.Error(), "out of memory") {
+		t.Errorf("error = %v, want the error in the body", err)
+	}
+
+	srv.Close()
+	if _, err := c.SendMessage("hi"); err == nil || !strings.Contains(err.Error(), "is Ollama running") {
+		t.Errorf("error = %v, want a hint that Ollama is down", err)
+	}
+}
@@ -0,0 +1,131 @@
+package openai
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/guidebee/jobseeker/pkg/apierror"
+)
+
+const (
+	// DefaultBaseURL is OpenAI's own API; point BaseURL at any server with an
+	// OpenAI-compatible chat completions endpoint (vLLM, LM Studio, llama.cpp,
+	// OpenRouter...) to use it instead
+	DefaultBaseURL   = "https://api.openai.com/v1"
+	DefaultModel     = "gpt-4o-mini"
+	DefaultMaxTokens = 4096
+)
+
+// Client handles communication with an OpenAI-compatible chat completions API
+type Client struct {
+	APIKey     string // optional for local servers
+	BaseURL    string
+	Model      string
+	HTTPClient *http.Client
+}
+
+// NewClient creates a new client for the API at baseURL, or OpenAI's own API
+// if baseURL is empty
+func NewClient(baseURL, apiKey string) *Client {
+	if baseURL == "" {
+		baseURL = DefaultBaseURL
+	}
+	return &Client{
+		APIKey:  apiKey,
+		BaseURL: strings.TrimSuffix(baseURL, "/"),
+		Model:   DefaultModel,
+		HTTPClient: &http.Client{
+			Timeout: 120 * time.Second, // local models answer slower than hosted ones
+		},
+	}
+}
+
+type message struct {
+	Role    string `json:"role"`
+	Content string `json:"content"`
+}
+
+type request struct {
+	Model     string    `json:"model"`
+	Messages  []message `json:"messages"`
+	MaxTokens int       `json:"max_tokens"`
+}
+
+type response struct {
+	Choices []struct {
+		Message struct {
+			Content string `json:"content"`
+		} `json:"message"`
+		FinishReason string `json:"finish_reason"`
+	} `json:"choices"`
+	Usage struct {
+		PromptTokens     int `json:"prompt_tokens"`
+		CompletionTokens int `json:"completion_tokens"`
+		TotalTokens      int `json:"total_tokens"`
+	} `json:"usage"`
+}
+
+// SendMessage sends a user message to the chat completions endpoint and
+// returns the text response
+func (c *Client) SendMessage(userMessage string) (string, error) {
+	reply, _, _, err := c.SendMessageUsage(userMessage)
+	return reply, err
+}
+
+// SendMessageUsage is SendMessage, also returning the tokens used by the
+// prompt and the reply
+func (c *Client) SendMessageUsage(userMessage string) (reply string, inputTokens, outputTokens int, err error) {
+	reqBody := request{
+		Model: c.Model,
+		Messages: []message{
+			{Role: "user", Content: userMessage},
+		},
+		MaxTokens: DefaultMaxTokens,
+	}
+
+	jsonData, err := json.Marshal(reqBody)
+	if err != nil {
+		return "", 0, 0, fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	req, err := http.NewRequest("POST", c.BaseURL+"/chat/completions", bytes.NewBuffer(jsonData))
+	if err != nil {
+		return "", 0, 0, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	if c.APIKey != "" {
+		req.Header.Set("Authorization", "Bearer "+c.APIKey)
+	}
+
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return "", 0, 0, fmt.Errorf("failed to send request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", 0, 0, fmt.Errorf("failed to read response: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return "", 0, 0, apierror.New(resp, body)
+	}
+
+	var apiResp response
+	if err := json.Unmarshal(body, &apiResp); err != nil {
+		return "", 0, 0, fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	if len(apiResp.Choices) > 0 {
+		return apiResp.Choices[0].Message.Content, apiResp.Usage.PromptTokens, apiResp.Usage.CompletionTokens, nil
+	}
+
+	return "", 0, 0, fmt.Errorf("no content in response")
+}
@@ -0,0 +1,73 @@
+package openai
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestSendMessage(t *testing.T) {
+	var got request
+	var auth string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/v1/chat/completions" {
+			t.Errorf("request to %s %s", r.Method, r.URL.Path)
+		}
+		auth = r.Header.Get("Authorization")
+		if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+			t.Error(err)
+		}
+		w.Write([]byte(`{"choices":[{"message":{"role":"assistant","content":"{\"match_score\": 80}"},"finish_reason":"stop"}],"usage":{"prompt_tokens":12,"completion_tokens":5,"total_tokens":17}}`)) //nolint:errcheck
+	}))
+	defer srv.Close()
+
+	c := NewClient(srv.URL+"/v1/", "sk-test")
+	c.Model = "qwen2.5:14b"
+	reply, in, out, err := c.SendMessageUsage("Score this job")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if reply != `{"match_score": 80}` || in != 12 || out != 5 {
+		t.Errorf("reply = %q, tokens %d in, %d out", reply, in, out)
+	}
+	if got.Model != "qwen2.5:14b" || len(got.Messages) != 1 || got.Messages[0].Role != "user" || got.Messages[0].Content != "Score this job" {
+		t.Errorf("request = %+v", got)
+	}
+	if auth != "Bearer sk-test" {
+		t.Errorf("Authorization = %q", auth)
+	}
+}
+
+func TestSendMessageWithoutKey(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if _, ok := r.Header["Authorization"]; ok {
+			t.Error("sent an Authorization header without an API key")
+		}
+		w.Write([]byte(`{"choices":[{"message":{"content":"ok"}}]}`)) //nolint:errcheck
+	}))
+	defer srv.Close()
+
+	if reply, err := NewClient(srv.URL, "").SendMessage("hi"); err != nil || reply != "ok" {
+		t.Errorf("SendMessage = %q, %v", reply, err)
+	}
+}
+
+func TestSendMessageErrors(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.Contains(r.URL.Path, "empty") {
+			w.Write([]byte(`{"choices":[]}`)) //nolint:errcheck
+			return
+		}
+		http.Error(w, `{"error":{"message":"model not found"}}`, http.StatusNotFound)
+	}))
+	defer srv.Close()
+
+	if _, err := NewClient(srv.URL, "").SendMessage("hi"); err == nil || !strings.Contains(err.Error(), "status 404") {
+		t.Errorf("error = %v, want the 404", err)
+	}
+	if _, err := NewClient(srv.URL+"/empty", "").SendMessage("hi"); err == nil {
+		t.Error("an empty reply was not an error")
+	}
+}